@@ -249,14 +249,17 @@ var skipExtensions = map[string]bool{
 var spinnerFrames = []string{"|", "/", "-", "\\", "|", "/", "-", "\\"}
 
 const (
-	colorPurple     = "\033[0;35m"
-	colorPurpleBold = "\033[1;35m"
-	colorGray       = "\033[0;90m"
-	colorRed        = "\033[0;31m"
-	colorYellow     = "\033[0;33m"
-	colorGreen      = "\033[0;32m"
-	colorBlue       = "\033[0;34m"
-	colorCyan       = "\033[0;36m"
-	colorReset      = "\033[0m"
-	colorBold       = "\033[1m"
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+)
+
+// Theme colors, set by applyTheme before the UI starts.
+var (
+	colorTitle     string
+	colorSubtle    string
+	colorDanger    string
+	colorWarn      string
+	colorOK        string
+	colorInfo      string
+	colorHighlight string
 )
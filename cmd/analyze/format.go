@@ -5,8 +5,34 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/tw93/mole/internal/theme"
+	"github.com/tw93/mole/internal/units"
+)
+
+var (
+	// activeTheme is the palette behind the color variables.
+	activeTheme = theme.Dark
+	// unitFmt formats sizes and counts; MO_UNITS adjusts it at startup.
+	unitFmt = units.Default()
 )
 
+func init() {
+	applyTheme(activeTheme)
+}
+
+// applyTheme sets the color variables from t.
+func applyTheme(t theme.Theme) {
+	activeTheme = t
+	colorTitle = t.ANSI(theme.Title)
+	colorSubtle = t.ANSI(theme.Subtle)
+	colorDanger = t.ANSI(theme.Danger)
+	colorWarn = t.ANSI(theme.Warn)
+	colorOK = t.ANSI(theme.OK)
+	colorInfo = t.ANSI(theme.Info)
+	colorHighlight = t.ANSI(theme.Highlight)
+}
+
 func displayPath(path string) string {
 	home, err := os.UserHomeDir()
 	if err != nil || home == "" {
@@ -66,54 +92,43 @@ func truncateMiddle(s string, maxWidth int) string {
 	return string(runes[:headIdx]) + "..." + string(runes[tailIdx:])
 }
 
-func formatNumber(n int64) string {
-	if n < 1000 {
-		return fmt.Sprintf("%d", n)
-	}
-	if n < 1000000 {
-		return fmt.Sprintf("%.1fk", float64(n)/1000)
-	}
-	return fmt.Sprintf("%.1fM", float64(n)/1000000)
-}
-
 func humanizeBytes(size int64) string {
 	if size < 0 {
 		return "0 B"
 	}
-	const unit = 1024
-	if size < unit {
-		return fmt.Sprintf("%d B", size)
-	}
-	div, exp := int64(unit), 0
-	for n := size / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	value := float64(size) / float64(div)
-	return fmt.Sprintf("%.1f %cB", value, "KMGTPE"[exp])
+	return unitFmt.Bytes(uint64(size))
 }
 
 func coloredProgressBar(value, maxValue int64, percent float64) string {
 	if maxValue <= 0 {
-		return colorGray + strings.Repeat("░", barWidth) + colorReset
+		return colorSubtle + strings.Repeat("░", barWidth) + colorReset
 	}
 
 	filled := min(int((value*int64(barWidth))/maxValue), barWidth)
 
 	var barColor string
+	level := theme.LevelOK
 	if percent >= 50 {
-		barColor = colorRed
+		barColor = colorDanger
+		level = theme.LevelDanger
 	} else if percent >= 20 {
-		barColor = colorYellow
+		barColor = colorWarn
+		level = theme.LevelWarn
 	} else if percent >= 5 {
-		barColor = colorBlue
+		barColor = colorInfo
 	} else {
-		barColor = colorGreen
+		barColor = colorOK
 	}
+	// Monochrome themes show severity in the last cell instead.
+	mark := activeTheme.Mark(level)
 
 	var bar strings.Builder
 	bar.WriteString(barColor)
 	for i := range barWidth {
+		if mark != "" && i == barWidth-1 {
+			bar.WriteString(colorReset + mark)
+			break
+		}
 		if i < filled {
 			if i < filled-1 {
 				bar.WriteString("█")
@@ -128,7 +143,7 @@ func coloredProgressBar(value, maxValue int64, percent float64) string {
 				}
 			}
 		} else {
-			bar.WriteString(colorGray + "░" + barColor)
+			bar.WriteString(colorSubtle + "░" + barColor)
 		}
 	}
 	return bar.String() + colorReset
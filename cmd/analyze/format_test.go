@@ -1,9 +1,12 @@
 package main
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/tw93/mole/internal/theme"
 )
 
 func TestRuneWidth(t *testing.T) {
@@ -64,14 +67,14 @@ func TestHumanizeBytes(t *testing.T) {
 		{0, "0 B"},
 		{512, "512 B"},
 		{1023, "1023 B"},
-		{1024, "1.0 KB"},
-		{1536, "1.5 KB"},
-		{10240, "10.0 KB"},
-		{1048576, "1.0 MB"},
-		{1572864, "1.5 MB"},
-		{1073741824, "1.0 GB"},
-		{1099511627776, "1.0 TB"},
-		{1125899906842624, "1.0 PB"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{10240, "10.0 KiB"},
+		{1048576, "1.0 MiB"},
+		{1572864, "1.5 MiB"},
+		{1073741824, "1.0 GiB"},
+		{1099511627776, "1.0 TiB"},
+		{1125899906842624, "1.0 PiB"},
 	}
 
 	for _, tt := range tests {
@@ -82,29 +85,6 @@ func TestHumanizeBytes(t *testing.T) {
 	}
 }
 
-func TestFormatNumber(t *testing.T) {
-	tests := []struct {
-		input int64
-		want  string
-	}{
-		{0, "0"},
-		{500, "500"},
-		{999, "999"},
-		{1000, "1.0k"},
-		{1500, "1.5k"},
-		{999999, "1000.0k"},
-		{1000000, "1.0M"},
-		{1500000, "1.5M"},
-	}
-
-	for _, tt := range tests {
-		got := formatNumber(tt.input)
-		if got != tt.want {
-			t.Errorf("formatNumber(%d) = %q, want %q", tt.input, got, tt.want)
-		}
-	}
-}
-
 func TestTruncateMiddle(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -347,3 +327,31 @@ func TestFormatUnusedTime(t *testing.T) {
 		})
 	}
 }
+
+var ansiPattern = regexp.MustCompile("\033\\[[0-9;]*m")
+
+func TestColoredProgressBarMono(t *testing.T) {
+	applyTheme(theme.Mono)
+	t.Cleanup(func() { applyTheme(theme.Dark) })
+
+	for _, tt := range []struct {
+		percent float64
+		last    string
+	}{
+		{80, "✖"},
+		{30, "▲"},
+		{2, "░"},
+	} {
+		bar := coloredProgressBar(10, 100, tt.percent)
+		if strings.Contains(bar, "\033[3") {
+			t.Errorf("%v%%: mono bar has color: %q", tt.percent, bar)
+		}
+		plain := ansiPattern.ReplaceAllString(bar, "")
+		if n := len([]rune(plain)); n != barWidth {
+			t.Errorf("%v%%: width %d, want %d", tt.percent, n, barWidth)
+		}
+		if !strings.HasSuffix(plain, tt.last) {
+			t.Errorf("%v%%: bar %q should end with %q", tt.percent, plain, tt.last)
+		}
+	}
+}
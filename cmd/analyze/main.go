@@ -14,6 +14,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tw93/mole/internal/theme"
 )
 
 type dirEntry struct {
@@ -153,6 +154,12 @@ func main() {
 	defer prefetchCancel()
 	go prefetchOverviewCache(prefetchCtx)
 
+	applyTheme(theme.Resolve(os.Getenv("MO_THEME")))
+	var err error
+	if unitFmt, err = unitFmt.FromEnv(); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
 	p := tea.NewProgram(newModel(abs, isOverview), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "analyzer error: %v\n", err)
@@ -536,7 +543,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.deleting && m.deleteCount != nil {
 				count := atomic.LoadInt64(m.deleteCount)
 				if count > 0 {
-					m.status = fmt.Sprintf("Moving to Trash... %s items", formatNumber(count))
+					m.status = fmt.Sprintf("Moving to Trash... %s items", unitFmt.CountCompact(count))
 				}
 			}
 			return m, tickCmd()
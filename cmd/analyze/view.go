@@ -14,7 +14,7 @@ func (m model) View() string {
 	fmt.Fprintln(&b)
 
 	if m.inOverviewMode() {
-		fmt.Fprintf(&b, "%sAnalyze Disk%s\n", colorPurpleBold, colorReset)
+		fmt.Fprintf(&b, "%sAnalyze Disk%s\n", colorTitle, colorReset)
 		if m.overviewScanning {
 			allPending := true
 			for _, entry := range m.entries {
@@ -26,13 +26,13 @@ func (m model) View() string {
 
 			if allPending {
 				fmt.Fprintf(&b, "%s%s%s%s Analyzing disk usage, please wait...%s\n",
-					colorCyan, colorBold,
+					colorHighlight, colorBold,
 					spinnerFrames[m.spinner],
 					colorReset, colorReset)
 				return b.String()
 			} else {
-				fmt.Fprintf(&b, "%sSelect a location to explore:%s  ", colorGray, colorReset)
-				fmt.Fprintf(&b, "%s%s%s%s %s\n\n", colorCyan, colorBold, spinnerFrames[m.spinner], colorReset, m.status)
+				fmt.Fprintf(&b, "%sSelect a location to explore:%s  ", colorSubtle, colorReset)
+				fmt.Fprintf(&b, "%s%s%s%s %s\n\n", colorHighlight, colorBold, spinnerFrames[m.spinner], colorReset, m.status)
 			}
 		} else {
 			hasPending := false
@@ -43,14 +43,14 @@ func (m model) View() string {
 				}
 			}
 			if hasPending {
-				fmt.Fprintf(&b, "%sSelect a location to explore:%s  ", colorGray, colorReset)
-				fmt.Fprintf(&b, "%s%s%s%s %s\n\n", colorCyan, colorBold, spinnerFrames[m.spinner], colorReset, m.status)
+				fmt.Fprintf(&b, "%sSelect a location to explore:%s  ", colorSubtle, colorReset)
+				fmt.Fprintf(&b, "%s%s%s%s %s\n\n", colorHighlight, colorBold, spinnerFrames[m.spinner], colorReset, m.status)
 			} else {
-				fmt.Fprintf(&b, "%sSelect a location to explore:%s\n\n", colorGray, colorReset)
+				fmt.Fprintf(&b, "%sSelect a location to explore:%s\n\n", colorSubtle, colorReset)
 			}
 		}
 	} else {
-		fmt.Fprintf(&b, "%sAnalyze Disk%s  %s%s%s", colorPurpleBold, colorReset, colorGray, displayPath(m.path), colorReset)
+		fmt.Fprintf(&b, "%sAnalyze Disk%s  %s%s%s", colorTitle, colorReset, colorSubtle, displayPath(m.path), colorReset)
 		if !m.scanning {
 			fmt.Fprintf(&b, "  |  Total: %s", humanizeBytes(m.totalSize))
 		}
@@ -64,10 +64,10 @@ func (m model) View() string {
 		}
 
 		fmt.Fprintf(&b, "%s%s%s%s Deleting: %s%s items%s removed, please wait...\n",
-			colorCyan, colorBold,
+			colorHighlight, colorBold,
 			spinnerFrames[m.spinner],
 			colorReset,
-			colorYellow, formatNumber(count), colorReset)
+			colorWarn, unitFmt.CountCompact(count), colorReset)
 
 		return b.String()
 	}
@@ -86,24 +86,24 @@ func (m model) View() string {
 			if m.scanning && percent >= 100 {
 				percent = 99
 			}
-			progressPrefix = fmt.Sprintf(" %s%.0f%%%s", colorCyan, percent, colorReset)
+			progressPrefix = fmt.Sprintf(" %s%.0f%%%s", colorHighlight, percent, colorReset)
 		}
 
 		fmt.Fprintf(&b, "%s%s%s%s Scanning%s: %s%s files%s, %s%s dirs%s, %s%s%s\n",
-			colorCyan, colorBold,
+			colorHighlight, colorBold,
 			spinnerFrames[m.spinner],
 			colorReset,
 			progressPrefix,
-			colorYellow, formatNumber(filesScanned), colorReset,
-			colorYellow, formatNumber(dirsScanned), colorReset,
-			colorGreen, humanizeBytes(bytesScanned), colorReset)
+			colorWarn, unitFmt.CountCompact(filesScanned), colorReset,
+			colorWarn, unitFmt.CountCompact(dirsScanned), colorReset,
+			colorOK, humanizeBytes(bytesScanned), colorReset)
 
 		if m.currentPath != nil {
 			currentPath := m.currentPath.Load().(string)
 			if currentPath != "" {
 				shortPath := displayPath(currentPath)
 				shortPath = truncateMiddle(shortPath, 50)
-				fmt.Fprintf(&b, "%s%s%s\n", colorGray, shortPath, colorReset)
+				fmt.Fprintf(&b, "%s%s%s\n", colorSubtle, shortPath, colorReset)
 			}
 		}
 
@@ -131,23 +131,23 @@ func (m model) View() string {
 				paddedPath := padName(shortPath, nameWidth)
 				entryPrefix := "   "
 				nameColor := ""
-				sizeColor := colorGray
+				sizeColor := colorSubtle
 				numColor := ""
 
 				isMultiSelected := m.largeMultiSelected != nil && m.largeMultiSelected[file.Path]
 				selectIcon := "○"
 				if isMultiSelected {
-					selectIcon = fmt.Sprintf("%s●%s", colorGreen, colorReset)
-					nameColor = colorGreen
+					selectIcon = fmt.Sprintf("%s●%s", colorOK, colorReset)
+					nameColor = colorOK
 				}
 
 				if idx == m.largeSelected {
-					entryPrefix = fmt.Sprintf(" %s%s▶%s ", colorCyan, colorBold, colorReset)
+					entryPrefix = fmt.Sprintf(" %s%s▶%s ", colorHighlight, colorBold, colorReset)
 					if !isMultiSelected {
-						nameColor = colorCyan
+						nameColor = colorHighlight
 					}
-					sizeColor = colorCyan
-					numColor = colorCyan
+					sizeColor = colorHighlight
+					numColor = colorHighlight
 				}
 				size := humanizeBytes(file.Size)
 				bar := coloredProgressBar(file.Size, maxLargeSize, 0)
@@ -188,17 +188,17 @@ func (m model) View() string {
 					if sizeVal >= 0 {
 						sizeText = humanizeBytes(sizeVal)
 					}
-					sizeColor := colorGray
+					sizeColor := colorSubtle
 					if sizeVal >= 0 && totalSize > 0 {
 						switch {
 						case percent >= 50:
-							sizeColor = colorRed
+							sizeColor = colorDanger
 						case percent >= 20:
-							sizeColor = colorYellow
+							sizeColor = colorWarn
 						case percent >= 5:
-							sizeColor = colorBlue
+							sizeColor = colorInfo
 						default:
-							sizeColor = colorGray
+							sizeColor = colorSubtle
 						}
 					}
 					entryPrefix := "   "
@@ -208,20 +208,20 @@ func (m model) View() string {
 					numColor := ""
 					percentColor := ""
 					if idx == m.selected {
-						entryPrefix = fmt.Sprintf(" %s%s▶%s ", colorCyan, colorBold, colorReset)
-						nameSegment = fmt.Sprintf("%s%s %s%s", colorCyan, icon, paddedName, colorReset)
-						numColor = colorCyan
-						percentColor = colorCyan
-						sizeColor = colorCyan
+						entryPrefix = fmt.Sprintf(" %s%s▶%s ", colorHighlight, colorBold, colorReset)
+						nameSegment = fmt.Sprintf("%s%s %s%s", colorHighlight, icon, paddedName, colorReset)
+						numColor = colorHighlight
+						percentColor = colorHighlight
+						sizeColor = colorHighlight
 					}
 					displayIndex := idx + 1
 
 					var hintLabel string
 					if entry.IsDir && isCleanableDir(entry.Path) {
-						hintLabel = fmt.Sprintf("%s🧹%s", colorYellow, colorReset)
+						hintLabel = fmt.Sprintf("%s🧹%s", colorWarn, colorReset)
 					} else {
 						if unusedTime := formatUnusedTime(entry.LastAccess); unusedTime != "" {
-							hintLabel = fmt.Sprintf("%s%s%s", colorGray, unusedTime, colorReset)
+							hintLabel = fmt.Sprintf("%s%s%s", colorSubtle, unusedTime, colorReset)
 						}
 					}
 
@@ -265,21 +265,21 @@ func (m model) View() string {
 
 					var sizeColor string
 					if percent >= 50 {
-						sizeColor = colorRed
+						sizeColor = colorDanger
 					} else if percent >= 20 {
-						sizeColor = colorYellow
+						sizeColor = colorWarn
 					} else if percent >= 5 {
-						sizeColor = colorBlue
+						sizeColor = colorInfo
 					} else {
-						sizeColor = colorGray
+						sizeColor = colorSubtle
 					}
 
 					isMultiSelected := m.multiSelected != nil && m.multiSelected[entry.Path]
 					selectIcon := "○"
 					nameColor := ""
 					if isMultiSelected {
-						selectIcon = fmt.Sprintf("%s●%s", colorGreen, colorReset)
-						nameColor = colorGreen
+						selectIcon = fmt.Sprintf("%s●%s", colorOK, colorReset)
+						nameColor = colorOK
 					}
 
 					entryPrefix := "   "
@@ -290,23 +290,23 @@ func (m model) View() string {
 					numColor := ""
 					percentColor := ""
 					if idx == m.selected {
-						entryPrefix = fmt.Sprintf(" %s%s▶%s ", colorCyan, colorBold, colorReset)
+						entryPrefix = fmt.Sprintf(" %s%s▶%s ", colorHighlight, colorBold, colorReset)
 						if !isMultiSelected {
-							nameSegment = fmt.Sprintf("%s%s %s%s", colorCyan, icon, paddedName, colorReset)
+							nameSegment = fmt.Sprintf("%s%s %s%s", colorHighlight, icon, paddedName, colorReset)
 						}
-						numColor = colorCyan
-						percentColor = colorCyan
-						sizeColor = colorCyan
+						numColor = colorHighlight
+						percentColor = colorHighlight
+						sizeColor = colorHighlight
 					}
 
 					displayIndex := idx + 1
 
 					var hintLabel string
 					if entry.IsDir && isCleanableDir(entry.Path) {
-						hintLabel = fmt.Sprintf("%s🧹%s", colorYellow, colorReset)
+						hintLabel = fmt.Sprintf("%s🧹%s", colorWarn, colorReset)
 					} else {
 						if unusedTime := formatUnusedTime(entry.LastAccess); unusedTime != "" {
-							hintLabel = fmt.Sprintf("%s%s%s", colorGray, unusedTime, colorReset)
+							hintLabel = fmt.Sprintf("%s%s%s", colorSubtle, unusedTime, colorReset)
 						}
 					}
 
@@ -327,31 +327,31 @@ func (m model) View() string {
 	fmt.Fprintln(&b)
 	if m.inOverviewMode() {
 		if len(m.history) > 0 {
-			fmt.Fprintf(&b, "%s↑↓←→ | Enter | R Refresh | O Open | F File | ← Back | Q Quit%s\n", colorGray, colorReset)
+			fmt.Fprintf(&b, "%s↑↓←→ | Enter | R Refresh | O Open | F File | ← Back | Q Quit%s\n", colorSubtle, colorReset)
 		} else {
-			fmt.Fprintf(&b, "%s↑↓→ | Enter | R Refresh | O Open | F File | Q Quit%s\n", colorGray, colorReset)
+			fmt.Fprintf(&b, "%s↑↓→ | Enter | R Refresh | O Open | F File | Q Quit%s\n", colorSubtle, colorReset)
 		}
 	} else if m.showLargeFiles {
 		selectCount := len(m.largeMultiSelected)
 		if selectCount > 0 {
-			fmt.Fprintf(&b, "%s↑↓← | Space Select | R Refresh | O Open | F File | ⌫ Del %d | ← Back | Q Quit%s\n", colorGray, selectCount, colorReset)
+			fmt.Fprintf(&b, "%s↑↓← | Space Select | R Refresh | O Open | F File | ⌫ Del %d | ← Back | Q Quit%s\n", colorSubtle, selectCount, colorReset)
 		} else {
-			fmt.Fprintf(&b, "%s↑↓← | Space Select | R Refresh | O Open | F File | ⌫ Del | ← Back | Q Quit%s\n", colorGray, colorReset)
+			fmt.Fprintf(&b, "%s↑↓← | Space Select | R Refresh | O Open | F File | ⌫ Del | ← Back | Q Quit%s\n", colorSubtle, colorReset)
 		}
 	} else {
 		largeFileCount := len(m.largeFiles)
 		selectCount := len(m.multiSelected)
 		if selectCount > 0 {
 			if largeFileCount > 0 {
-				fmt.Fprintf(&b, "%s↑↓←→ | Space Select | Enter | R Refresh | O Open | F File | ⌫ Del %d | T Top %d | Q Quit%s\n", colorGray, selectCount, largeFileCount, colorReset)
+				fmt.Fprintf(&b, "%s↑↓←→ | Space Select | Enter | R Refresh | O Open | F File | ⌫ Del %d | T Top %d | Q Quit%s\n", colorSubtle, selectCount, largeFileCount, colorReset)
 			} else {
-				fmt.Fprintf(&b, "%s↑↓←→ | Space Select | Enter | R Refresh | O Open | F File | ⌫ Del %d | Q Quit%s\n", colorGray, selectCount, colorReset)
+				fmt.Fprintf(&b, "%s↑↓←→ | Space Select | Enter | R Refresh | O Open | F File | ⌫ Del %d | Q Quit%s\n", colorSubtle, selectCount, colorReset)
 			}
 		} else {
 			if largeFileCount > 0 {
-				fmt.Fprintf(&b, "%s↑↓←→ | Space Select | Enter | R Refresh | O Open | F File | ⌫ Del | T Top %d | Q Quit%s\n", colorGray, largeFileCount, colorReset)
+				fmt.Fprintf(&b, "%s↑↓←→ | Space Select | Enter | R Refresh | O Open | F File | ⌫ Del | T Top %d | Q Quit%s\n", colorSubtle, largeFileCount, colorReset)
 			} else {
-				fmt.Fprintf(&b, "%s↑↓←→ | Space Select | Enter | R Refresh | O Open | F File | ⌫ Del | Q Quit%s\n", colorGray, colorReset)
+				fmt.Fprintf(&b, "%s↑↓←→ | Space Select | Enter | R Refresh | O Open | F File | ⌫ Del | Q Quit%s\n", colorSubtle, colorReset)
 			}
 		}
 	}
@@ -383,14 +383,14 @@ func (m model) View() string {
 
 		if deleteCount > 1 {
 			fmt.Fprintf(&b, "%sDelete:%s %d items, %s  %sPress Enter to confirm  |  ESC cancel%s\n",
-				colorRed, colorReset,
+				colorDanger, colorReset,
 				deleteCount, humanizeBytes(totalDeleteSize),
-				colorGray, colorReset)
+				colorSubtle, colorReset)
 		} else {
 			fmt.Fprintf(&b, "%sDelete:%s %s, %s  %sPress Enter to confirm  |  ESC cancel%s\n",
-				colorRed, colorReset,
+				colorDanger, colorReset,
 				m.deleteTarget.Name, humanizeBytes(m.deleteTarget.Size),
-				colorGray, colorReset)
+				colorSubtle, colorReset)
 		}
 	}
 	return b.String()
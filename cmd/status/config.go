@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const configUsage = `usage: mo status config [show | path | get KEY | set KEY VALUE | reset | edit]
+
+keys: ` + "%s"
+
+// prefField reads and writes one preference as text for `mo status config`.
+type prefField struct {
+	key string
+	get func(p statusPrefs) string
+	set func(p *statusPrefs, value string) error
+}
+
+var prefFields = []prefField{
+	{
+		key: "refresh_interval",
+		get: func(p statusPrefs) string { return time.Duration(p.Interval).String() },
+		set: func(p *statusPrefs, v string) error {
+			return (&p.Interval).UnmarshalText([]byte(v))
+		},
+	},
+	{
+		key: "theme",
+		get: func(p statusPrefs) string { return p.Theme },
+		set: func(p *statusPrefs, v string) error { p.Theme = v; return nil },
+	},
+	{
+		key: "units.bytes",
+		get: func(p statusPrefs) string { return p.Units.Bytes },
+		set: func(p *statusPrefs, v string) error { p.Units.Bytes = v; return nil },
+	},
+	{
+		key: "units.network",
+		get: func(p statusPrefs) string { return p.Units.Network },
+		set: func(p *statusPrefs, v string) error { p.Units.Network = v; return nil },
+	},
+	{
+		key: "units.temperature",
+		get: func(p statusPrefs) string { return p.Units.Temperature },
+		set: func(p *statusPrefs, v string) error { p.Units.Temperature = v; return nil },
+	},
+	{
+		key: "layout.cards",
+		get: func(p statusPrefs) string { return strings.Join(p.Layout.Cards, ",") },
+		set: func(p *statusPrefs, v string) error { p.Layout.Cards = splitList(v); return nil },
+	},
+	{
+		key: "layout.columns",
+		get: func(p statusPrefs) string { return strconv.Itoa(p.Layout.Columns) },
+		set: func(p *statusPrefs, v string) error {
+			n, err := strconv.Atoi(v)
+			p.Layout.Columns = n
+			return err
+		},
+	},
+	{
+		key: "animation",
+		get: func(p statusPrefs) string { return strconv.FormatBool(p.Animation) },
+		set: func(p *statusPrefs, v string) error {
+			b, err := strconv.ParseBool(v)
+			p.Animation = b
+			return err
+		},
+	},
+	{
+		key: "cat_hidden",
+		get: func(p statusPrefs) string { return strconv.FormatBool(p.CatHidden) },
+		set: func(p *statusPrefs, v string) error {
+			b, err := strconv.ParseBool(v)
+			p.CatHidden = b
+			return err
+		},
+	},
+}
+
+func findPrefField(key string) (prefField, error) {
+	for _, f := range prefFields {
+		if f.key == key {
+			return f, nil
+		}
+	}
+	return prefField{}, fmt.Errorf("%w %q", errUnknownPref, key)
+}
+
+// runConfig implements `mo status config`.
+func runConfig(args []string, path string, out io.Writer) error {
+	cmd := "show"
+	if len(args) > 0 {
+		cmd, args = args[0], args[1:]
+	}
+	if path == "" {
+		return fmt.Errorf("cannot locate the home directory for %s", "status.json")
+	}
+
+	prefs, loadErr := loadPrefs(path)
+	switch {
+	case cmd == "show" && len(args) == 0:
+		if loadErr != nil {
+			fmt.Fprintf(out, "# warning: %v\n", loadErr)
+		}
+		data, err := json.MarshalIndent(prefs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "# %s\n%s\n", path, data)
+		return nil
+	case cmd == "path" && len(args) == 0:
+		fmt.Fprintln(out, path)
+		return nil
+	case cmd == "get" && len(args) == 1:
+		f, err := findPrefField(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, f.get(prefs))
+		return nil
+	case cmd == "set" && len(args) == 2:
+		f, err := findPrefField(args[0])
+		if err != nil {
+			return err
+		}
+		if err := f.set(&prefs, args[1]); err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		if err := prefs.validate(); err != nil {
+			return err
+		}
+		if err := savePrefs(path, prefs); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s = %s\n", f.key, f.get(prefs))
+		return nil
+	case cmd == "reset" && len(args) == 0:
+		return savePrefs(path, defaultPrefs())
+	case cmd == "edit" && len(args) == 0:
+		if _, err := os.Stat(path); err != nil {
+			// Start from the current (possibly migrated) values.
+			if err := savePrefs(path, prefs); err != nil {
+				return err
+			}
+		}
+		if err := runEditor(path); err != nil {
+			return err
+		}
+		if _, err := loadPrefs(path); err != nil {
+			return fmt.Errorf("saved, but %w; invalid values fall back to defaults", err)
+		}
+		return nil
+	}
+
+	keys := make([]string, 0, len(prefFields))
+	for _, f := range prefFields {
+		keys = append(keys, f.key)
+	}
+	return fmt.Errorf(configUsage, strings.Join(keys, ", "))
+}
+
+// runEditor opens path in $VISUAL or $EDITOR, defaulting to vi.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// The variable may carry flags, e.g. "code --wait".
+	fields := strings.Fields(editor)
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxColumns     = 4
+	narrowWidth    = 80 // At or below this, auto layout uses one column
+	minColumnWidth = 30 // Columns are dropped rather than squeezed below this
+)
+
+// cardLayout is the dashboard arrangement from the layout preferences.
+type cardLayout struct {
+	cards   []string // Source names in display order; empty shows every card
+	columns int      // 0 picks one or two columns by terminal width
+}
+
+func newCardLayout(cards []string, columns int) cardLayout {
+	var l cardLayout
+	for _, name := range cards {
+		if name = strings.TrimSpace(name); name != "" {
+			l.cards = append(l.cards, name)
+		}
+	}
+	l.columns = max(0, min(columns, maxColumns))
+	return l
+}
+
+// sources returns the sources whose cards are shown, in layout order.
+// Unknown names are ignored.
+func (l cardLayout) sources(all []MetricSource) []MetricSource {
+	if len(l.cards) == 0 {
+		return all
+	}
+	byName := make(map[string]MetricSource, len(all))
+	for _, src := range all {
+		byName[src.Name()] = src
+	}
+	var out []MetricSource
+	for _, name := range l.cards {
+		if src, ok := byName[name]; ok {
+			out = append(out, src)
+			delete(byName, name) // Listed twice shows once.
+		}
+	}
+	return out
+}
+
+// columnCount is the configured column count, reduced to fit width.
+func (l cardLayout) columnCount(width int) int {
+	if l.columns == 0 {
+		if width <= narrowWidth {
+			return 1
+		}
+		return 2
+	}
+	if width <= 0 {
+		return l.columns
+	}
+	return max(1, min(l.columns, width/minColumnWidth))
+}
+
+// renderColumns lays cards out row by row, padding each row's cards to the
+// same height. One column stacks cards at their natural width.
+func renderColumns(cards []cardData, width, columns int) string {
+	if len(cards) == 0 {
+		return ""
+	}
+	var rows []string
+	if columns <= 1 {
+		cw := 0
+		if width > narrowWidth {
+			cw = width - 4
+		}
+		for _, c := range cards {
+			rows = append(rows, renderCard(c, cw, 0))
+		}
+	} else {
+		cw := colWidth
+		if width > 0 && (width-2*(columns-1))/columns > cw {
+			cw = (width - 2*(columns-1)) / columns
+		}
+		for i := 0; i < len(cards); i += columns {
+			row := cards[i:min(i+columns, len(cards))]
+			height := 0
+			for _, c := range row {
+				height = max(height, lipgloss.Height(renderCard(c, cw, 0)))
+			}
+			var rendered []string
+			for j, c := range row {
+				if j > 0 {
+					rendered = append(rendered, "  ")
+				}
+				rendered = append(rendered, renderCard(c, cw, height))
+			}
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, rendered...))
+		}
+	}
+
+	var spacedRows []string
+	for i, r := range rows {
+		if i > 0 {
+			spacedRows = append(spacedRows, "")
+		}
+		spacedRows = append(spacedRows, r)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, spacedRows...)
+}
+
+// moveFocus steps delta cards from current through names, clamped at the
+// ends. With nothing focused the first card takes focus.
+func moveFocus(names []string, current string, delta int) string {
+	if len(names) == 0 {
+		return ""
+	}
+	idx := -1
+	for i, name := range names {
+		if name == current {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return names[0]
+	}
+	return names[max(0, min(len(names)-1, idx+delta))]
+}
+
+// cardZoomer is implemented by sources with a full-screen card variant.
+type cardZoomer interface {
+	ZoomCard(s MetricsSnapshot, width, height int) (cardData, bool)
+}
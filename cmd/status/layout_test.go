@@ -0,0 +1,157 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func testCardSources(names ...string) []MetricSource {
+	var sources []MetricSource
+	for _, name := range names {
+		sources = append(sources, &funcSource[int]{
+			name: name,
+			card: func(MetricsSnapshot, int) cardData {
+				return cardData{title: strings.ToUpper(name), lines: []string{name + " line"}}
+			},
+		})
+	}
+	return sources
+}
+
+func sourceNames(sources []MetricSource) string {
+	var names []string
+	for _, src := range sources {
+		names = append(names, src.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+func TestNewCardLayout(t *testing.T) {
+	l := newCardLayout([]string{"network", " cpu", "", "bogus", "cpu"}, 9)
+	if l.columns != maxColumns {
+		t.Errorf("columns = %d, want capped at %d", l.columns, maxColumns)
+	}
+	all := testCardSources("cpu", "memory", "network")
+	if got := sourceNames(l.sources(all)); got != "network,cpu" {
+		t.Errorf("sources = %s", got)
+	}
+
+	def := newCardLayout(nil, -1)
+	if def.columns != 0 || sourceNames(def.sources(all)) != "cpu,memory,network" {
+		t.Errorf("default layout = %+v", def)
+	}
+}
+
+func TestColumnCount(t *testing.T) {
+	tests := []struct {
+		columns, width, want int
+	}{
+		{0, 80, 1},
+		{0, 120, 2},
+		{3, 200, 3},
+		{3, 70, 2},  // Too narrow for three
+		{2, 20, 1},  // Never below one
+		{1, 200, 1}, // Asked for one
+	}
+	for _, tt := range tests {
+		if got := (cardLayout{columns: tt.columns}).columnCount(tt.width); got != tt.want {
+			t.Errorf("columns=%d width=%d: got %d, want %d", tt.columns, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestRenderColumns(t *testing.T) {
+	cards := buildCards(testCardSources("a", "b", "c", "d"), MetricsSnapshot{}, 0)
+	cards[2].lines = append(cards[2].lines, "extra")
+
+	out := renderColumns(cards, 150, 3)
+	rows := strings.Split(out, "\n")
+	if !strings.Contains(rows[0], "A") || !strings.Contains(rows[0], "B") || !strings.Contains(rows[0], "C") {
+		t.Errorf("first row should hold three cards:\n%s", out)
+	}
+	// The row is padded to the tallest card, then a blank line and D.
+	if lipgloss.Height(out) != 3+1+2 || !strings.Contains(rows[4], "D") {
+		t.Errorf("unexpected layout:\n%s", out)
+	}
+	if w := lipgloss.Width(rows[0]); w > 150 {
+		t.Errorf("row width %d exceeds terminal", w)
+	}
+
+	stacked := renderColumns(cards, 60, 1)
+	if lipgloss.Height(stacked) != 2+1+2+1+3+1+2 {
+		t.Errorf("one column should stack every card:\n%s", stacked)
+	}
+}
+
+func TestMoveFocus(t *testing.T) {
+	names := []string{"cpu", "memory", "disk", "network"}
+	tests := []struct {
+		current string
+		delta   int
+		want    string
+	}{
+		{"", 1, "cpu"},
+		{"gone", -1, "cpu"},
+		{"cpu", 1, "memory"},
+		{"cpu", 2, "disk"},
+		{"disk", 5, "network"},
+		{"memory", -3, "cpu"},
+	}
+	for _, tt := range tests {
+		if got := moveFocus(names, tt.current, tt.delta); got != tt.want {
+			t.Errorf("moveFocus(%q, %d) = %q, want %q", tt.current, tt.delta, got, tt.want)
+		}
+	}
+}
+
+func TestFocusAndZoomKeys(t *testing.T) {
+	sources := testCardSources("cpu", "memory", "disk")
+	sources[0].(*funcSource[int]).zoom = func(_ MetricsSnapshot, width, height int) cardData {
+		return cardData{title: "CPU ZOOMED", lines: []string{"history"}}
+	}
+	m := model{collector: newTestCollector(sources...), ready: true, width: 120, height: 40}
+	press := func(k tea.KeyType) {
+		t.Helper()
+		next, cmd := m.Update(tea.KeyMsg{Type: k})
+		if cmd != nil {
+			t.Fatalf("key %v returned a command", k)
+		}
+		m = next.(model)
+	}
+
+	press(tea.KeyRight)
+	press(tea.KeyDown) // Two columns: down moves past memory to disk.
+	if m.focus != "disk" {
+		t.Fatalf("focus = %q, want disk", m.focus)
+	}
+	press(tea.KeyLeft)
+	press(tea.KeyLeft)
+	press(tea.KeyEnter)
+	if !m.zoomed || m.focus != "cpu" {
+		t.Fatalf("zoomed = %v, focus = %q", m.zoomed, m.focus)
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "CPU ZOOMED") || strings.Contains(view, "MEMORY") {
+		t.Errorf("zoom view:\n%s", view)
+	}
+
+	// Cards without a zoom variant are widened.
+	press(tea.KeyRight)
+	if view := stripANSI(m.View()); !strings.Contains(view, "memory line") || strings.Contains(view, "DISK") {
+		t.Errorf("default zoom view:\n%s", view)
+	}
+
+	press(tea.KeyEsc)
+	if m.zoomed || m.focus != "memory" {
+		t.Errorf("esc should leave zoom first: zoomed=%v focus=%q", m.zoomed, m.focus)
+	}
+	press(tea.KeyEsc)
+	if m.focus != "" {
+		t.Errorf("esc should clear focus, got %q", m.focus)
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd == nil {
+		t.Error("esc with nothing focused should quit")
+	}
+}
@@ -2,14 +2,20 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"slices"
 	"strings"
+	"syscall"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tw93/mole/internal/theme"
 )
 
 const refreshInterval = time.Second
@@ -19,12 +25,22 @@ var (
 	BuildTime = ""
 )
 
-type tickMsg struct{}
+// tickMsg triggers a collection. Ticks from an earlier generation were
+// scheduled before a pause or interval change and are dropped.
+type tickMsg struct {
+	gen int
+}
+
+// refreshSteps are the intervals the +/- keys move between.
+var refreshSteps = []time.Duration{
+	250 * time.Millisecond, 500 * time.Millisecond, time.Second,
+	2 * time.Second, 5 * time.Second, 10 * time.Second,
+}
+
 type animTickMsg struct{}
 
 type metricsMsg struct {
 	data MetricsSnapshot
-	err  error
 }
 
 type model struct {
@@ -38,95 +54,243 @@ type model struct {
 	collecting  bool
 	animFrame   int
 	catHidden   bool // true = hidden, false = visible
+	procPanel   processPanel
+	diskView    bool        // Full-screen volume and device list
+	netIface    string      // Interface shown in the network card; "" for all
+	prefs       statusPrefs // As loaded from disk, without flag overrides
+	layout      cardLayout
+	focus       string // Name of the focused card; "" for none
+	zoomed      bool   // Focused card expanded to the full screen
+	interval    time.Duration
+	paused      bool // Collection stopped; the last snapshot stays on screen
+	tickGen     int
+	hosts       []remoteHost // Remote machines; empty when monitoring only this one
+	hostIdx     int          // 0 is the local machine, i is hosts[i-1]
+	remoteCh    chan remoteMsg
 }
 
-// getConfigPath returns the path to the status preferences file.
-func getConfigPath() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return ""
+func newModel(prefs statusPrefs) model {
+	return model{
+		collector: NewCollector(),
+		prefs:     prefs,
+		interval:  time.Duration(prefs.Interval),
+		catHidden: prefs.CatHidden,
+		layout:    prefs.cardLayout(),
 	}
-	return filepath.Join(home, ".config", "mole", "status_prefs")
 }
 
-// loadCatHidden loads the cat hidden preference from config file.
-func loadCatHidden() bool {
-	path := getConfigPath()
-	if path == "" {
-		return false
+// withRemotes starts streaming from each transport and adds it as a host.
+func (m model) withRemotes(ctx context.Context, transports []commandTransport) model {
+	if len(transports) == 0 {
+		return m
 	}
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return false
+	m.remoteCh = make(chan remoteMsg)
+	for i, t := range transports {
+		m.hosts = append(m.hosts, remoteHost{name: t.name})
+		go runRemote(ctx, t, i, m.remoteCh)
 	}
-	return strings.TrimSpace(string(data)) == "cat_hidden=true"
+	return m
 }
 
-// saveCatHidden saves the cat hidden preference to config file.
-func saveCatHidden(hidden bool) {
-	path := getConfigPath()
-	if path == "" {
-		return
+func (m model) Init() tea.Cmd {
+	cmds := []tea.Cmd{m.tickAfter(0)}
+	if m.prefs.Animation {
+		cmds = append(cmds, animTick())
 	}
-	// Ensure directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return
+	if m.remoteCh != nil {
+		cmds = append(cmds, waitRemote(m.remoteCh))
 	}
-	value := "cat_hidden=false"
-	if hidden {
-		value = "cat_hidden=true"
+	return tea.Batch(cmds...)
+}
+
+// selected returns the snapshot and header message of the host on screen.
+func (m model) selected() (MetricsSnapshot, string, bool) {
+	if m.hostIdx == 0 {
+		return m.metrics, m.errMessage, m.ready
 	}
-	_ = os.WriteFile(path, []byte(value+"\n"), 0644)
+	h := m.hosts[m.hostIdx-1]
+	return h.metrics, h.errMessage, h.ready
 }
 
-func newModel() model {
-	return model{
-		collector: NewCollector(),
-		catHidden: loadCatHidden(),
+func (m model) hostNames() []string {
+	names := []string{"local"}
+	for _, h := range m.hosts {
+		names = append(names, h.name)
 	}
+	return names
 }
 
-func (m model) Init() tea.Cmd {
-	return tea.Batch(tickAfter(0), animTick())
+func (m model) selectHost(idx int) model {
+	if idx < 0 || idx > len(m.hosts) || idx == m.hostIdx {
+		return m
+	}
+	m.hostIdx = idx
+	m.procPanel.selectedPID = 0
+	m.procPanel.selectedRow = 0
+	m.procPanel.confirm = nil
+	m.procPanel.message = ""
+	return m
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc", "ctrl+c":
+		if m.procPanel.open {
+			key := msg.String()
+			if key == "ctrl+c" || (key == "q" && !m.procPanel.filtering && m.procPanel.confirm == nil) {
+				return m, tea.Quit
+			}
+			if (key == "x" || key == "X") && !m.procPanel.filtering && m.procPanel.confirm == nil {
+				switch {
+				case m.hostIdx != 0:
+					m.procPanel.message = "Signals can only be sent to local processes"
+					return m, nil
+				case m.collector.replay != nil:
+					m.procPanel.message = "Signals are disabled while replaying a recording"
+					return m, nil
+				}
+			}
+			snap, _, _ := m.selected()
+			var cmd tea.Cmd
+			m.procPanel, cmd = m.procPanel.update(msg, snap.Processes, m.processPanelHeight()-4)
+			return m, cmd
+		}
+		if m.diskView {
+			if key := msg.String(); key == "d" || key == "esc" {
+				m.diskView = false
+				return m, nil
+			}
+		}
+		switch key := msg.String(); key {
+		case "esc":
+			// Leave zoom, then focus, before quitting.
+			switch {
+			case m.zoomed:
+				m.zoomed = false
+			case m.focus != "":
+				m.focus = ""
+			default:
+				return m, tea.Quit
+			}
+			return m, nil
+		case "left", "right", "up", "down":
+			step := 1
+			if key == "up" || key == "down" {
+				step = m.layout.columnCount(m.width)
+				if m.zoomed {
+					step = 1
+				}
+			}
+			if key == "left" || key == "up" {
+				step = -step
+			}
+			m.focus = moveFocus(m.cardNames(), m.focus, step)
+			return m, nil
+		case "enter", "z":
+			if m.focus == "" {
+				m.focus = moveFocus(m.cardNames(), "", 0)
+			}
+			m.zoomed = !m.zoomed && m.focus != ""
+			return m, nil
+		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "tab":
+			return m.selectHost((m.hostIdx + 1) % (len(m.hosts) + 1)), nil
+		case "shift+tab":
+			return m.selectHost((m.hostIdx + len(m.hosts)) % (len(m.hosts) + 1)), nil
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			return m.selectHost(int(key[0] - '1')), nil
+		case "p":
+			m.procPanel.open = true
+			return m, nil
+		case "d":
+			m.diskView = true
+			return m, nil
+		case "n":
+			snap, _, _ := m.selected()
+			m.netIface = nextInterface(snap.Network, m.netIface)
+			return m, nil
+		case " ":
+			m.paused = !m.paused
+			m.tickGen++
+			if !m.paused {
+				m = m.applyPending()
+			}
+			if !m.paused && !m.collecting {
+				return m, m.tickAfter(0)
+			}
+			return m, nil
+		case ".":
+			// Single step: collect once while paused.
+			if !m.paused || m.collecting {
+				return m, nil
+			}
+			m = m.applyPending()
+			m.collecting = true
+			return m, m.collectCmd()
+		case "+", "=", "-", "_":
+			faster := key == "+" || key == "="
+			m.interval = stepInterval(m.refreshInterval(), faster)
+			if m.paused || m.collecting {
+				// The next tick is scheduled when the collection finishes.
+				return m, nil
+			}
+			m.tickGen++
+			return m, m.tickAfter(m.interval)
 		case "k":
-			// Toggle cat visibility and persist preference
+			// Toggle cat visibility and persist it over the file as it is now,
+			// keeping any `mo status config set` made while running.
 			m.catHidden = !m.catHidden
-			saveCatHidden(m.catHidden)
+			prefs, _ := loadPrefs(prefsPath())
+			prefs.CatHidden = m.catHidden
+			if savePrefs(prefsPath(), prefs) == nil {
+				m.prefs = prefs
+			}
 			return m, nil
 		}
+	case signalResultMsg:
+		sig := signalName(msg.req.kill)
+		if msg.err != nil {
+			m.procPanel.message = fmt.Sprintf("%s to %s (%d) failed: %v", sig, msg.req.name, msg.req.pid, msg.err)
+		} else {
+			m.procPanel.message = fmt.Sprintf("Sent %s to %s (%d)", sig, msg.req.name, msg.req.pid)
+		}
+		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, nil
 	case tickMsg:
-		if m.collecting {
+		if m.collecting || m.paused || msg.gen != m.tickGen {
 			return m, nil
 		}
 		m.collecting = true
 		return m, m.collectCmd()
 	case metricsMsg:
-		if msg.err != nil {
-			m.errMessage = msg.err.Error()
-		} else {
-			m.errMessage = ""
-		}
+		// Per-source failures are reported on their cards; the header only
+		// lists which sources are affected.
+		m.errMessage = sourceIssues(msg.data.Sources)
 		m.metrics = msg.data
 		m.lastUpdated = msg.data.CollectedAt
 		m.collecting = false
+		if m.procPanel.open && m.hostIdx == 0 {
+			m.procPanel = m.procPanel.follow(msg.data.Processes)
+		}
 		// Mark ready after first successful data collection.
 		if !m.ready {
 			m.ready = true
 		}
-		return m, tickAfter(refreshInterval)
+		if m.paused {
+			return m, nil
+		}
+		return m, m.tickAfter(m.refreshInterval())
+	case remoteMsg:
+		if m.paused {
+			// Shown on resume or the next single step.
+			m.hosts[msg.host].pending = &msg
+			return m, waitRemote(m.remoteCh)
+		}
+		return m.applyRemote(msg), waitRemote(m.remoteCh)
 	case animTickMsg:
 		m.animFrame++
 		return m, animTickWithSpeed(m.metrics.CPU.Usage)
@@ -134,51 +298,189 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// applyRemote shows a remote host's latest snapshot or connection error.
+func (m model) applyRemote(msg remoteMsg) model {
+	h := &m.hosts[msg.host]
+	h.pending = nil
+	if msg.err != nil {
+		h.errMessage = fmt.Sprintf("%s unreachable: %v", h.name, msg.err)
+		return m
+	}
+	h.metrics = msg.data
+	h.errMessage = sourceIssues(msg.data.Sources)
+	h.ready = true
+	if m.procPanel.open && m.hostIdx == msg.host+1 {
+		m.procPanel = m.procPanel.follow(msg.data.Processes)
+	}
+	return m
+}
+
+// applyPending shows the remote messages held back while paused.
+func (m model) applyPending() model {
+	for _, h := range m.hosts {
+		if h.pending != nil {
+			m = m.applyRemote(*h.pending)
+		}
+	}
+	return m
+}
+
 func (m model) View() string {
-	if !m.ready {
+	snap, errMessage, ready := m.selected()
+	if !ready {
+		if errMessage != "" {
+			return m.hostTabs() + errMessage + "\n"
+		}
+		if m.hostIdx != 0 {
+			return m.hostTabs() + "Connecting..."
+		}
 		return "Loading..."
 	}
 
-	header := renderHeader(m.metrics, m.errMessage, m.animFrame, m.width, m.catHidden)
+	header := m.header()
+	if m.procPanel.open {
+		return header + "\n" + renderProcessPanel(m.procPanel, snap.Processes, m.width, m.processPanelHeight())
+	}
+	if m.diskView {
+		return header + "\n" + renderDiskPanel(snap.Disks, snap.DiskIO, m.width, m.processPanelHeight())
+	}
+	if m.zoomed {
+		if zoom, ok := m.zoomCard(snap); ok {
+			return header + "\n" + zoom
+		}
+	}
+
+	columns := m.layout.columnCount(m.width)
 	cardWidth := 0
-	if m.width > 80 {
-		cardWidth = max(24, m.width/2-4)
+	if m.width > narrowWidth {
+		cardWidth = max(24, m.width/columns-4)
+	}
+	cards := buildCards(m.layout.sources(m.collector.Sources()), focusNetwork(snap, m.netIface), cardWidth)
+	for i := range cards {
+		cards[i].focused = cards[i].name == m.focus
+	}
+
+	body := renderColumns(cards, m.width, columns)
+	// Add extra newline if cat is hidden for better spacing
+	if m.catHidden {
+		return header + "\n\n" + body
+	}
+	return header + "\n" + body
+}
+
+// cardNames lists the cards currently on the dashboard, in layout order.
+func (m model) cardNames() []string {
+	snap, _, _ := m.selected()
+	var names []string
+	for _, c := range buildCards(m.layout.sources(m.collector.Sources()), snap, 0) {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// zoomCard renders the focused card across the whole screen; ok is false
+// when it is no longer shown.
+func (m model) zoomCard(snap MetricsSnapshot) (string, bool) {
+	width := max(m.width, colWidth) - 2
+	height := m.processPanelHeight() - 2
+	for _, src := range m.layout.sources(m.collector.Sources()) {
+		if src.Name() != m.focus {
+			continue
+		}
+		zoomer, ok := src.(cardZoomer)
+		if !ok {
+			return "", false
+		}
+		card, ok := zoomer.ZoomCard(focusNetwork(snap, m.netIface), width, height)
+		if !ok {
+			return "", false
+		}
+		card.badge = cardBadge(src, snap.Sources)
+		return renderCard(card, width, height) + "\n\n" + subtleStyle.Render("←→ switch card  enter/esc back"), true
+	}
+	return "", false
+}
+
+// refreshInterval is the current delay between collections.
+func (m model) refreshInterval() time.Duration {
+	if m.interval <= 0 {
+		return refreshInterval
 	}
-	cards := buildCards(m.metrics, cardWidth)
+	return m.interval
+}
+
+// tickAfter schedules the next collection in the current generation.
+func (m model) tickAfter(delay time.Duration) tea.Cmd {
+	gen := m.tickGen
+	return tea.Tick(delay, func(time.Time) tea.Msg { return tickMsg{gen: gen} })
+}
 
-	if m.width <= 80 {
-		var rendered []string
-		for i, c := range cards {
-			if i > 0 {
-				rendered = append(rendered, "")
+// stepInterval moves to the next shorter or longer refresh step.
+func stepInterval(cur time.Duration, faster bool) time.Duration {
+	if faster {
+		for i := len(refreshSteps) - 1; i >= 0; i-- {
+			if refreshSteps[i] < cur {
+				return refreshSteps[i]
 			}
-			rendered = append(rendered, renderCard(c, cardWidth, 0))
 		}
-		result := header + "\n" + lipgloss.JoinVertical(lipgloss.Left, rendered...)
-		// Add extra newline if cat is hidden for better spacing
-		if m.catHidden {
-			result = header + "\n\n" + lipgloss.JoinVertical(lipgloss.Left, rendered...)
+		return refreshSteps[0]
+	}
+	for _, d := range refreshSteps {
+		if d > cur {
+			return d
 		}
-		return result
 	}
+	return refreshSteps[len(refreshSteps)-1]
+}
 
-	twoCol := renderTwoColumns(cards, m.width)
-	// Add extra newline if cat is hidden for better spacing
-	if m.catHidden {
-		return header + "\n\n" + twoCol
+// processPanelHeight returns the rows left for the process panel below the header.
+func (m model) processPanelHeight() int {
+	return max(m.height-lipgloss.Height(m.header())-1, 8)
+}
+
+// header renders the host switcher, if any, above the selected host's header.
+func (m model) header() string {
+	snap, errMessage, _ := m.selected()
+	header := renderHeader(snap, errMessage, m.animFrame, m.width, m.catHidden)
+	if status := m.refreshStatus(); status != "" {
+		first, rest, _ := strings.Cut(header, "\n")
+		header = first + "  " + status
+		if rest != "" {
+			header += "\n" + rest
+		}
 	}
-	return header + "\n" + twoCol
+	return m.hostTabs() + header
 }
 
-func (m model) collectCmd() tea.Cmd {
-	return func() tea.Msg {
-		data, err := m.collector.Collect()
-		return metricsMsg{data: data, err: err}
+// refreshStatus marks a paused dashboard or a non-default refresh interval.
+func (m model) refreshStatus() string {
+	var parts []string
+	if m.collector.replay != nil {
+		shown, total := m.collector.replay.position()
+		parts = append(parts, primaryStyle.Render(fmt.Sprintf("▶ replay %d/%d", shown, total)))
+	}
+	switch {
+	case m.paused:
+		parts = append(parts, warnStyle.Render("❚❚ paused")+subtleStyle.Render(" · space resume · . step"))
+	case m.refreshInterval() != refreshInterval:
+		parts = append(parts, subtleStyle.Render("⟳ "+m.refreshInterval().String()))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m model) hostTabs() string {
+	if len(m.hosts) == 0 {
+		return ""
 	}
+	return renderHostTabs(m.hostNames(), m.hostIdx) + "\n"
 }
 
-func tickAfter(delay time.Duration) tea.Cmd {
-	return tea.Tick(delay, func(time.Time) tea.Msg { return tickMsg{} })
+func (m model) collectCmd() tea.Cmd {
+	return func() tea.Msg {
+		// Errors are carried per source in data.Sources.
+		data, _ := m.collector.Collect()
+		return metricsMsg{data: data}
+	}
 }
 
 func animTick() tea.Cmd {
@@ -191,8 +493,137 @@ func animTickWithSpeed(cpuUsage float64) tea.Cmd {
 	return tea.Tick(time.Duration(interval)*time.Millisecond, func(time.Time) tea.Msg { return animTickMsg{} })
 }
 
+// printJSON writes a single snapshot to stdout. Rates need two samples, so
+// the collector is primed once before the reported collection.
+func printJSON() error {
+	collector := NewCollector()
+	_, _ = collector.Collect()
+	time.Sleep(refreshInterval)
+	data, err := collector.Collect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
 func main() {
-	p := tea.NewProgram(newModel(), tea.WithAltScreen())
+	jsonOutput := flag.Bool("json", false, "print one snapshot as JSON and exit")
+	agent := flag.Bool("agent", false, "stream snapshots as JSON lines for a remote mo status")
+	remotes := flag.String("remote", "", "comma-separated SSH hosts to monitor alongside this machine")
+	remoteCommand := flag.String("remote-command", defaultRemoteCommand, "command run on remote hosts over SSH")
+	interval := flag.Duration("interval", 0, "refresh interval, 250ms to 10s (default from mo status config)")
+	record := flag.String("record", "", "write every snapshot and the raw command output behind it to `file`")
+	replay := flag.String("replay", "", "show a recording made with -record instead of this machine")
+	themeName := flag.String("theme", "", "color theme: "+strings.Join(theme.Names, ", ")+" (default from mo status config)")
+	flag.BoolVar(&showCgroupCard, "cgroups", false, "show a per-cgroup and systemd unit breakdown card (Linux)")
+	flag.Func("probes", "comma-separated connectivity probes: tcp://host:port, dns://name, http(s)://url, or none", func(raw string) error {
+		specs, err := parseProbes(raw)
+		if err == nil {
+			probeSpecs = specs
+		}
+		return err
+	})
+	flag.Parse()
+
+	if flag.Arg(0) == "config" {
+		if err := runConfig(flag.Args()[1:], prefsPath(), os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "mo status config: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *interval != 0 && (*interval < minRefreshInterval || *interval > maxRefreshInterval) {
+		fmt.Fprintf(os.Stderr, "-interval %s: must be between %s and %s\n", *interval, minRefreshInterval, maxRefreshInterval)
+		os.Exit(2)
+	}
+	if *record != "" && *replay != "" {
+		fmt.Fprintln(os.Stderr, "-record and -replay cannot be combined")
+		os.Exit(2)
+	}
+	if *themeName != "" && !slices.Contains(theme.Names, *themeName) {
+		fmt.Fprintf(os.Stderr, "-theme %q: must be one of %s\n", *themeName, strings.Join(theme.Names, ", "))
+		os.Exit(2)
+	}
+
+	prefs, err := loadPrefs(prefsPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v; using defaults\n", err)
+	}
+	// Units also label the JSON output, so apply them before any mode.
+	if unitFmt, err = prefs.formatter(); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *agent {
+		agentInterval := refreshInterval
+		if *interval != 0 {
+			agentInterval = *interval
+		}
+		if err := runAgent(ctx, NewCollector(), os.Stdout, agentInterval); err != nil {
+			fmt.Fprintf(os.Stderr, "system status agent error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *jsonOutput {
+		if err := printJSON(); err != nil {
+			fmt.Fprintf(os.Stderr, "system status error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var transports []commandTransport
+	for host := range strings.SplitSeq(*remotes, ",") {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+		if strings.HasPrefix(host, "-") {
+			fmt.Fprintf(os.Stderr, "-remote %q: host cannot start with -\n", host)
+			os.Exit(2)
+		}
+		transports = append(transports, sshTransport(host, *remoteCommand))
+	}
+
+	// Flags override the preferences for this run only; prefs stays as
+	// loaded so saving it never persists them.
+	themeChoice := prefs.Theme
+	if *themeName != "" {
+		themeChoice = *themeName
+	}
+	applyTheme(resolveTheme(themeChoice))
+
+	m := newModel(prefs)
+	if *interval != 0 {
+		m.interval = *interval
+	}
+	if *record != "" {
+		rec, err := newSessionRecorder(*record)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "-record: %v\n", err)
+			os.Exit(1)
+		}
+		defer rec.Close()
+		m.collector.record = rec
+	}
+	if *replay != "" {
+		rp, err := loadReplay(*replay)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "-replay %s: %v\n", *replay, err)
+			os.Exit(1)
+		}
+		m.collector.replay = rp
+	}
+
+	p := tea.NewProgram(m.withRemotes(ctx, transports), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "system status error: %v\n", err)
 		os.Exit(1)
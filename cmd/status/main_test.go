@@ -0,0 +1,149 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+func TestStepInterval(t *testing.T) {
+	tests := []struct {
+		cur    time.Duration
+		faster bool
+		want   time.Duration
+	}{
+		{time.Second, true, 500 * time.Millisecond},
+		{time.Second, false, 2 * time.Second},
+		{250 * time.Millisecond, true, 250 * time.Millisecond},
+		{10 * time.Second, false, 10 * time.Second},
+		{3 * time.Second, true, 2 * time.Second}, // Off-step values from --interval
+		{3 * time.Second, false, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := stepInterval(tt.cur, tt.faster); got != tt.want {
+			t.Errorf("stepInterval(%s, faster=%v) = %s, want %s", tt.cur, tt.faster, got, tt.want)
+		}
+	}
+}
+
+func TestPauseAndStep(t *testing.T) {
+	m := model{collector: newTestCollector(), ready: true, width: 100, interval: time.Second}
+	update := func(msg tea.Msg) tea.Cmd {
+		t.Helper()
+		next, cmd := m.Update(msg)
+		m = next.(model)
+		return cmd
+	}
+	key := func(k string) tea.Cmd { return update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}) }
+	staleTick := tickMsg{gen: m.tickGen}
+
+	if cmd := update(tea.KeyMsg{Type: tea.KeySpace}); cmd != nil || !m.paused {
+		t.Fatalf("space should pause without scheduling: paused=%v", m.paused)
+	}
+	if !strings.Contains(stripANSI(m.header()), "paused") {
+		t.Error("header should show the pause")
+	}
+	// A tick scheduled before the pause must not collect.
+	if cmd := update(staleTick); cmd != nil || m.collecting {
+		t.Error("stale tick collected while paused")
+	}
+
+	// Step collects exactly once and schedules nothing after it.
+	if cmd := key("."); cmd == nil || !m.collecting {
+		t.Fatal("step should start a collection")
+	}
+	if cmd := key("."); cmd != nil {
+		t.Error("step while collecting should wait")
+	}
+	if cmd := update(metricsMsg{data: MetricsSnapshot{Uptime: "3d"}}); cmd != nil || m.metrics.Uptime != "3d" {
+		t.Error("stepped snapshot should show without resuming the tick loop")
+	}
+
+	if cmd := update(tea.KeyMsg{Type: tea.KeySpace}); cmd == nil || m.paused {
+		t.Fatal("space should resume and tick right away")
+	}
+	if cmd := update(staleTick); cmd != nil {
+		t.Error("tick from before the pause collected after resuming")
+	}
+	if cmd := key("."); cmd != nil {
+		t.Error("step only applies while paused")
+	}
+}
+
+func TestRefreshKeysChangeInterval(t *testing.T) {
+	m := model{collector: newTestCollector(), ready: true, width: 100}
+	gen := m.tickGen
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m = next.(model)
+	if m.refreshInterval() != 500*time.Millisecond || cmd == nil || m.tickGen == gen {
+		t.Fatalf("+ gave %s, rescheduled=%v", m.refreshInterval(), cmd != nil)
+	}
+	if !strings.Contains(stripANSI(m.header()), "⟳ 500ms") {
+		t.Errorf("header missing interval:\n%s", stripANSI(m.header()))
+	}
+	for range 10 {
+		next, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+		m = next.(model)
+	}
+	if m.refreshInterval() != 10*time.Second {
+		t.Errorf("- capped at %s", m.refreshInterval())
+	}
+
+	// While a collection runs, the change waits for its result.
+	m.collecting = true
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")}); cmd != nil {
+		t.Error("interval change scheduled a second tick loop")
+	}
+}
+
+// Saving the cat preference writes the file's own settings, not the
+// interval the dashboard happens to run at.
+func TestCatToggleKeepsSavedInterval(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := newModel(defaultPrefs())
+	m.collector = newTestCollector()
+	m.interval = 3 * time.Second // As set by -interval
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m = next.(model)
+
+	saved, err := loadPrefs(prefsPath())
+	if err != nil || !saved.CatHidden || time.Duration(saved.Interval) != refreshInterval {
+		t.Errorf("saved = %+v, %v", saved, err)
+	}
+	if m.refreshInterval() != 3*time.Second {
+		t.Errorf("running interval = %s", m.refreshInterval())
+	}
+}
+
+func TestRatesScaleWithInterval(t *testing.T) {
+	prev := net.IOCountersStat{Name: "eth0", PacketsRecv: 100}
+	cur := net.IOCountersStat{Name: "eth0", BytesRecv: 1 << 20, PacketsRecv: 150}
+	for _, elapsed := range []float64{0.25, 1, 10} {
+		got := interfaceRates(prev, cur, elapsed)
+		if got.RxRateMBs != 1/elapsed || got.RxPackets != 50/elapsed {
+			t.Errorf("elapsed %v: %v MB/s, %v pkt/s", elapsed, got.RxRateMBs, got.RxPackets)
+		}
+	}
+}
+
+// A setting changed with `mo status config set` while the dashboard runs
+// survives the cat toggle.
+func TestCatToggleKeepsConfigChanges(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := newModel(defaultPrefs())
+	m.collector = newTestCollector()
+	if err := runConfig([]string{"set", "theme", "light"}, prefsPath(), io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m = next.(model)
+
+	saved, err := loadPrefs(prefsPath())
+	if err != nil || !saved.CatHidden || saved.Theme != "light" {
+		t.Errorf("saved = %+v, %v", saved, err)
+	}
+}
@@ -2,14 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os/exec"
 	"sync"
 	"time"
 
+	"github.com/shirou/gopsutil/v4/cpu"
 	"github.com/shirou/gopsutil/v4/disk"
-	"github.com/shirou/gopsutil/v4/host"
 	"github.com/shirou/gopsutil/v4/net"
+	"github.com/tw93/mole/internal/units"
 )
 
 // RingBuffer is a fixed-size circular buffer for float64 values.
@@ -70,15 +71,40 @@ type MetricsSnapshot struct {
 	GPU            []GPUStatus
 	Memory         MemoryStatus
 	Disks          []DiskStatus
+	Drives         []DriveHealth
 	DiskIO         DiskIOStatus
 	Network        []NetworkStatus
 	NetworkHistory NetworkHistory
+	Probes         []ProbeStatus
+	Connections    ConnectionStatus
 	Proxy          ProxyStatus
 	Batteries      []BatteryStatus
 	Thermal        ThermalStatus
 	Sensors        []SensorReading
+	Cgroup         CgroupStatus
 	Bluetooth      []BluetoothDevice
 	TopProcesses   []ProcessInfo
+	Processes      []ProcessInfo
+	TopIO          []ProcessInfo
+	Leaks          []LeakSuspect
+
+	// Sources reports freshness and failures per metric source.
+	Sources map[string]SourceStatus
+
+	Units UnitInfo
+}
+
+// UnitInfo documents the units of the numeric snapshot fields, which are
+// fixed, and the display units the dashboard was set to.
+type UnitInfo struct {
+	Sizes        string // Byte counts
+	Rates        string // Disk, network and process I/O rates
+	Temperatures string
+	Display      units.Meta
+}
+
+func snapshotUnits() UnitInfo {
+	return UnitInfo{Sizes: "bytes", Rates: "MiB/s", Temperatures: "celsius", Display: unitFmt.Meta()}
 }
 
 type HardwareInfo struct {
@@ -91,14 +117,47 @@ type HardwareInfo struct {
 }
 
 type DiskIOStatus struct {
-	ReadRate  float64 // MB/s
-	WriteRate float64 // MB/s
+	ReadRate  float64 // MiB/s from storage
+	WriteRate float64 // MiB/s to storage
+	Stall     float64 // % of time tasks waited on IO, last 10s (Linux PSI)
+	Devices   []DeviceIOStatus
+}
+
+// DeviceIOStatus is throughput for one physical disk.
+type DeviceIOStatus struct {
+	Name      string
+	ReadRate  float64 // MiB/s
+	WriteRate float64 // MiB/s
+	ReadIOPS  float64
+	WriteIOPS float64
+	Latency   float64 // Average ms per completed operation
 }
 
 type ProcessInfo struct {
+	PID       int32
+	PPID      int32
+	Name      string
+	User      string
+	CPU       float64 // Percent of one core
+	Memory    float64 // Percent of total RAM
+	RSS       uint64
+	Threads   int32
+	OpenFiles int32
+	ReadRate  float64 // MiB/s from storage
+	WriteRate float64 // MiB/s to storage
+	NetRxRate float64 // MiB/s received on sockets
+	NetTxRate float64 // MiB/s sent on sockets
+}
+
+// LeakSuspect is a process whose resident memory grew steadily over the
+// leak window.
+type LeakSuspect struct {
+	PID    int32
 	Name   string
-	CPU    float64
-	Memory float64
+	RSS    uint64
+	Growth uint64    // Bytes gained since Since
+	Rate   float64   // MiB/s averaged over the window
+	Since  time.Time // Oldest sample in the window
 }
 
 type CPUStatus struct {
@@ -110,8 +169,26 @@ type CPUStatus struct {
 	Load15           float64
 	CoreCount        int
 	LogicalCPU       int
-	PCoreCount       int // Performance cores (Apple Silicon)
-	ECoreCount       int // Efficiency cores (Apple Silicon)
+	PCoreCount       int     // Performance cores (Apple Silicon)
+	ECoreCount       int     // Efficiency cores (Apple Silicon)
+	PCoreUsage       float64 // Average usage of the performance cores
+	ECoreUsage       float64 // Average usage of the efficiency cores
+	Stall            float64 // % of time runnable tasks waited for a CPU, last 10s (Linux PSI)
+	Times            CPUTimes
+	FreqMHz          int         // Current clock averaged over cores; 0 if unknown
+	MaxFreqMHz       int         // Highest rated clock; 0 if unknown
+	PerCoreHistory   [][]float64 // Recent usage per core, oldest first
+}
+
+// CPUTimes is the share of CPU time spent in each state since the previous
+// sample, in percent across all cores.
+type CPUTimes struct {
+	User   float64 // Including nice
+	System float64
+	IOWait float64
+	IRQ    float64 // Hard and soft interrupts
+	Steal  float64 // Taken by the hypervisor
+	Idle   float64
 }
 
 type GPUStatus struct {
@@ -120,6 +197,9 @@ type GPUStatus struct {
 	MemoryUsed  float64
 	MemoryTotal float64
 	CoreCount   int
+	FreqMHz     int     // Current core clock; 0 if unknown
+	MaxFreqMHz  int     // Highest core clock level
+	Temp        float64 // Celsius; 0 if unknown
 	Note        string
 }
 
@@ -129,8 +209,31 @@ type MemoryStatus struct {
 	UsedPercent float64
 	SwapUsed    uint64
 	SwapTotal   uint64
-	Cached      uint64 // File cache that can be freed if needed
-	Pressure    string // macOS memory pressure: normal/warn/critical
+	Cached      uint64  // File cache that can be freed if needed
+	Pressure    string  // Memory pressure: normal/warn/critical (macOS, Linux PSI)
+	Stall       float64 // % of time tasks waited on memory, last 10s (Linux PSI)
+
+	// Kinds of memory, which depend on the OS: wired, active, inactive and
+	// compressed on macOS; anon, file, slab and shmem on Linux.
+	Breakdown      []MemorySegment
+	SwapInRate     float64 // MiB/s read back from swap
+	SwapOutRate    float64 // MiB/s written to swap
+	PageFaultRate  float64 // Faults per second
+	MajorFaultRate float64 // Faults per second that read from disk
+	Top            []MemoryConsumer
+}
+
+// MemorySegment is one kind of memory in MemoryStatus.Breakdown.
+type MemorySegment struct {
+	Name  string
+	Bytes uint64
+}
+
+// MemoryConsumer is one of the processes with the largest resident set.
+type MemoryConsumer struct {
+	PID  int32
+	Name string
+	RSS  uint64
 }
 
 type DiskStatus struct {
@@ -141,16 +244,74 @@ type DiskStatus struct {
 	UsedPercent float64
 	Fstype      string
 	External    bool
+
+	InodesUsed    uint64
+	InodesTotal   uint64
+	InodesPercent float64
+}
+
+// DriveHealth is SMART data for one physical drive.
+type DriveHealth struct {
+	Device       string
+	Model        string
+	Temp         float64 // Celsius; 0 if unknown
+	PercentUsed  int     // Rated endurance consumed; -1 if unknown
+	MediaErrors  uint64  // Uncorrectable read/write errors
+	PowerOnHours uint64
+	Reallocated  uint64 // Remapped sectors or NAND blocks
+	Failed       bool   // SMART overall assessment failed
+	Source       string // smartctl or sysfs
 }
 
 type NetworkStatus struct {
 	Name      string
-	RxRateMBs float64
-	TxRateMBs float64
+	RxRateMBs float64 // MiB/s
+	TxRateMBs float64 // MiB/s
 	IP        string
+	RxPackets float64 // Packets/s received
+	TxPackets float64 // Packets/s sent
+	Errors    uint64  // Cumulative receive and send errors
+	Drops     uint64  // Cumulative receive and send drops
+	History   NetworkHistory
 }
 
-// NetworkHistory holds the global network usage history.
+// ConnectionStatus summarizes listening sockets and open connections.
+type ConnectionStatus struct {
+	Listening   []ListeningSocket
+	Established int
+	TimeWait    int
+	CloseWait   int               // Peer closed but the local process hasn't; grows with leaks
+	ByRemote    []ConnectionCount // Established connections per remote IP, busiest first
+	ByProcess   []ConnectionCount // Established connections per process, busiest first
+}
+
+type ListeningSocket struct {
+	Proto   string // tcp or udp
+	Address string // "*" for all interfaces
+	Port    uint32
+	PID     int32
+	Process string
+}
+
+type ConnectionCount struct {
+	Name  string
+	Count int
+}
+
+// ProbeStatus is a connectivity check's recent results.
+type ProbeStatus struct {
+	Name        string // e.g. tcp://1.1.1.1:443
+	Kind        string // tcp, dns or http
+	Up          bool   // Whether the latest run succeeded
+	LastError   string
+	Latency     float64   // ms, latest run
+	AvgLatency  float64   // ms, successful runs in History
+	Jitter      float64   // ms, mean change between successful runs
+	FailureRate float64   // % of runs in History that failed
+	History     []float64 // ms per run, oldest first; 0 for failures
+}
+
+// NetworkHistory holds network usage history, aggregated or per interface.
 type NetworkHistory struct {
 	RxHistory []float64
 	TxHistory []float64
@@ -158,6 +319,9 @@ type NetworkHistory struct {
 
 const NetworkHistorySize = 120 // Increased history size for wider graph
 
+// cpuHistorySize is how many samples each core's sparkline keeps.
+const cpuHistorySize = 60
+
 type ProxyStatus struct {
 	Enabled bool
 	Type    string // HTTP, HTTPS, SOCKS, PAC, WPAD, TUN
@@ -190,6 +354,27 @@ type SensorReading struct {
 	Note  string
 }
 
+// CgroupStatus describes the cgroup mo status runs in (Linux only).
+type CgroupStatus struct {
+	Path        string  // Cgroup path, "/" at the root
+	Version     int     // 1 or 2; 0 without cgroups
+	CPULimit    float64 // Cores allowed by cpu.max or the CFS quota; 0 = unlimited
+	CPUUsage    float64 // Cores in use
+	MemoryLimit uint64  // 0 = unlimited
+	MemoryUsed  uint64
+	ReadRate    float64       // MiB/s
+	WriteRate   float64       // MiB/s
+	Children    []CgroupUsage // Busiest systemd units, or child cgroups in a container; v2 only
+}
+
+type CgroupUsage struct {
+	Name       string  // Path below the hierarchy root, e.g. system.slice/nginx.service
+	CPUUsage   float64 // Cores
+	MemoryUsed uint64
+	ReadRate   float64 // MiB/s
+	WriteRate  float64 // MiB/s
+}
+
 type BluetoothDevice struct {
 	Name      string
 	Connected bool
@@ -197,153 +382,143 @@ type BluetoothDevice struct {
 }
 
 type Collector struct {
-	// Static cache.
-	cachedHW  HardwareInfo
-	lastHWAt  time.Time
-	hasStatic bool
-
-	// Slow cache (30s-1m).
-	lastBTAt time.Time
-	lastBT   []BluetoothDevice
-
-	// Fast metrics (1s).
-	prevNet      map[string]net.IOCountersStat
-	lastNetAt    time.Time
-	rxHistoryBuf *RingBuffer
-	txHistoryBuf *RingBuffer
-	lastGPUAt    time.Time
-	cachedGPU    []GPUStatus
-	prevDiskIO   disk.IOCountersStat
-	lastDiskAt   time.Time
+	sources     []MetricSource
+	sourceMu    sync.Mutex
+	sourceState map[string]*sourceState
+
+	// Rate calculation state owned by individual sources.
+	prevCPUTimes  cpu.TimesStat
+	prevCoreTimes []cpu.TimesStat
+	prevPaging    pagingCounters
+	lastPagingAt  time.Time
+	coreHistory   []*RingBuffer
+	prevNet       map[string]net.IOCountersStat
+	lastNetAt     time.Time
+	rxHistoryBuf  *RingBuffer
+	txHistoryBuf  *RingBuffer
+	ifaceHistory  map[string]*netHistoryBuf
+	probeHistory  map[string][]probeSample
+	lastGPUAt     time.Time
+	cachedGPU     []GPUStatus
+	prevGPUIdle   map[string]gpuIdleSample
+	prevDiskIO    disk.IOCountersStat
+	prevDevIO     map[string]disk.IOCountersStat
+	lastDiskAt    time.Time
+	prevProcs     map[int32]procSample
+	lastProcAt    time.Time
+	rssHistory    map[int32]*rssHistory
+	userNames     map[uint32]string
+	prevCgroups   map[string]cgroupSample
+	lastCgroupAt  time.Time
+
+	// platform answers the collectors' commands and file reads.
+	platform *platform
+
+	// Set by --record and --replay.
+	record *sessionRecorder
+	replay *sessionReplay
 }
 
 func NewCollector() *Collector {
-	return &Collector{
+	c := &Collector{
+		sourceState:  make(map[string]*sourceState),
 		prevNet:      make(map[string]net.IOCountersStat),
 		rxHistoryBuf: NewRingBuffer(NetworkHistorySize),
 		txHistoryBuf: NewRingBuffer(NetworkHistorySize),
+		ifaceHistory: make(map[string]*netHistoryBuf),
+		prevProcs:    make(map[int32]procSample),
+		userNames:    make(map[uint32]string),
+		platform:     localPlatform,
+	}
+	for _, factory := range sourceFactories {
+		c.sources = append(c.sources, factory(c))
 	}
+	return c
 }
 
+// Sources returns the collector's metric sources in card order.
+func (c *Collector) Sources() []MetricSource {
+	return c.sources
+}
+
+// Collect refreshes every due source concurrently and assembles a snapshot
+// from the latest value of each. A slow source only delays the refresh until
+// its own timeout; its previous value is then reported stale in
+// MetricsSnapshot.Sources. The returned error joins every source failure.
 func (c *Collector) Collect() (MetricsSnapshot, error) {
+	if c.replay != nil {
+		return c.replay.snapshot(), nil
+	}
 	now := time.Now()
 
-	// Host info is cached by gopsutil; fetch once.
-	hostInfo, _ := host.Info()
-
 	var (
-		wg       sync.WaitGroup
-		errMu    sync.Mutex
-		mergeErr error
-
-		cpuStats     CPUStatus
-		memStats     MemoryStatus
-		diskStats    []DiskStatus
-		diskIO       DiskIOStatus
-		netStats     []NetworkStatus
-		proxyStats   ProxyStatus
-		batteryStats []BatteryStatus
-		thermalStats ThermalStatus
-		sensorStats  []SensorReading
-		gpuStats     []GPUStatus
-		btStats      []BluetoothDevice
-		topProcs     []ProcessInfo
+		wg    sync.WaitGroup
+		errMu sync.Mutex
+		errs  []error
 	)
-
-	// Helper to launch concurrent collection.
-	collect := func(fn func() error) {
+	for _, src := range c.sources {
+		if !c.due(src, now) {
+			continue
+		}
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := fn(); err != nil {
+			if err := c.runSource(src, now); err != nil {
 				errMu.Lock()
-				if mergeErr == nil {
-					mergeErr = err
-				} else {
-					mergeErr = fmt.Errorf("%v; %w", mergeErr, err)
-				}
+				errs = append(errs, err)
 				errMu.Unlock()
 			}
 		}()
 	}
-
-	// Launch independent collection tasks.
-	collect(func() (err error) { cpuStats, err = collectCPU(); return })
-	collect(func() (err error) { memStats, err = collectMemory(); return })
-	collect(func() (err error) { diskStats, err = collectDisks(); return })
-	collect(func() (err error) { diskIO = c.collectDiskIO(now); return nil })
-	collect(func() (err error) { netStats, err = c.collectNetwork(now); return })
-	collect(func() (err error) { proxyStats = collectProxy(); return nil })
-	collect(func() (err error) { batteryStats, _ = collectBatteries(); return nil })
-	collect(func() (err error) { thermalStats = collectThermal(); return nil })
-	// Sensors disabled - CPU temp already shown in CPU card
-	// collect(func() (err error) { sensorStats, _ = collectSensors(); return nil })
-	collect(func() (err error) { gpuStats, err = c.collectGPU(now); return })
-	collect(func() (err error) {
-		// Bluetooth is slow; cache for 30s.
-		if now.Sub(c.lastBTAt) > 30*time.Second || len(c.lastBT) == 0 {
-			btStats = c.collectBluetooth(now)
-			c.lastBT = btStats
-			c.lastBTAt = now
-		} else {
-			btStats = c.lastBT
-		}
-		return nil
-	})
-	collect(func() (err error) { topProcs = collectTopProcesses(); return nil })
-
-	// Wait for all to complete.
 	wg.Wait()
 
-	// Dependent tasks (post-collect).
-	// Cache hardware info as it's expensive and rarely changes.
-	if !c.hasStatic || now.Sub(c.lastHWAt) > 10*time.Minute {
-		c.cachedHW = collectHardware(memStats.Total, diskStats)
-		c.lastHWAt = now
-		c.hasStatic = true
+	snapshot := MetricsSnapshot{CollectedAt: now, Sources: c.statuses(), Units: snapshotUnits()}
+	for _, src := range c.sources {
+		src.Apply(&snapshot)
 	}
-	hwInfo := c.cachedHW
-
-	score, scoreMsg := calculateHealthScore(cpuStats, memStats, diskStats, diskIO, thermalStats)
-
-	return MetricsSnapshot{
-		CollectedAt:    now,
-		Host:           hostInfo.Hostname,
-		Platform:       fmt.Sprintf("%s %s", hostInfo.Platform, hostInfo.PlatformVersion),
-		Uptime:         formatUptime(hostInfo.Uptime),
-		Procs:          hostInfo.Procs,
-		Hardware:       hwInfo,
-		HealthScore:    score,
-		HealthScoreMsg: scoreMsg,
-		CPU:            cpuStats,
-		GPU:            gpuStats,
-		Memory:         memStats,
-		Disks:          diskStats,
-		DiskIO:         diskIO,
-		Network:        netStats,
-		NetworkHistory: NetworkHistory{
-			RxHistory: c.rxHistoryBuf.Slice(),
-			TxHistory: c.txHistoryBuf.Slice(),
-		},
-		Proxy:        proxyStats,
-		Batteries:    batteryStats,
-		Thermal:      thermalStats,
-		Sensors:      sensorStats,
-		Bluetooth:    btStats,
-		TopProcesses: topProcs,
-	}, mergeErr
+	scoreSnapshot(&snapshot)
+
+	if c.record != nil {
+		if err := c.record.write(snapshot); err != nil {
+			errs = append(errs, fmt.Errorf("record: %w", err))
+		}
+	}
+	return snapshot, errors.Join(errs...)
 }
 
+func scoreSnapshot(s *MetricsSnapshot) {
+	s.HealthScore, s.HealthScoreMsg = calculateHealthScore(s.CPU, s.Memory, s.Disks, s.DiskIO, s.Thermal, s.Drives, s.Leaks)
+}
+
+// runCmd runs name on the platform in ctx and returns its stdout. Under
+// --record the output is kept for the recording; a replaying tape answers
+// without running it.
 func runCmd(ctx context.Context, name string, args ...string) (string, error) {
-	cmd := exec.CommandContext(ctx, name, args...)
-	output, err := cmd.Output()
+	output, err := runCmdOutput(ctx, name, args...)
 	if err != nil {
 		return "", err
 	}
-	return string(output), nil
+	return output, nil
+}
+
+// runCmdOutput is runCmd but keeps whatever stdout a failed command wrote.
+func runCmdOutput(ctx context.Context, name string, args ...string) (string, error) {
+	tape := tapeFrom(ctx)
+	if tape != nil && tape.replay {
+		return tape.play(name, args)
+	}
+	output, err := platformFrom(ctx).run(ctx, name, args...)
+	if tape != nil {
+		rec := recordedCommand{Name: name, Args: args, Output: string(output)}
+		if err != nil {
+			rec.Error = err.Error()
+		}
+		tape.add(ctx, rec)
+	}
+	return string(output), err
 }
 
-func commandExists(name string) bool {
+func commandExists(ctx context.Context, name string) bool {
 	if name == "" {
 		return false
 	}
@@ -351,6 +526,6 @@ func commandExists(name string) bool {
 		// Treat LookPath panics as "missing".
 		_ = recover()
 	}()
-	_, err := exec.LookPath(name)
+	_, err := platformFrom(ctx).lookPath(name)
 	return err == nil
 }
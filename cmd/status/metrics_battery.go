@@ -4,22 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"math"
 	"path/filepath"
-	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
-var (
-	// Cache for heavy system_profiler output.
-	lastPowerAt   time.Time
-	cachedPower   string
-	powerCacheTTL = 30 * time.Second
-)
+// powerCacheTTL is how long the heavy system_profiler output is cached.
+const powerCacheTTL = 30 * time.Second
 
-func collectBatteries() (batts []BatteryStatus, err error) {
+func collectBatteries(ctx context.Context) (batts []BatteryStatus, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			// Swallow panics to keep UI alive.
@@ -27,11 +23,13 @@ func collectBatteries() (batts []BatteryStatus, err error) {
 		}
 	}()
 
+	p := platformFrom(ctx)
+
 	// macOS: pmset for real-time percentage/status.
-	if runtime.GOOS == "darwin" && commandExists("pmset") {
-		if out, err := runCmd(context.Background(), "pmset", "-g", "batt"); err == nil {
+	if p.goos == "darwin" && commandExists(ctx, "pmset") {
+		if out, err := runCmd(ctx, "pmset", "-g", "batt"); err == nil {
 			// Health/cycles/capacity from cached system_profiler.
-			health, cycles, capacity := getCachedPowerData()
+			health, cycles, capacity := getCachedPowerData(ctx)
 			if batts := parsePMSet(out, health, cycles, capacity); len(batts) > 0 {
 				return batts, nil
 			}
@@ -39,30 +37,138 @@ func collectBatteries() (batts []BatteryStatus, err error) {
 	}
 
 	// Linux: /sys/class/power_supply.
-	matches, _ := filepath.Glob("/sys/class/power_supply/BAT*/capacity")
-	for _, capFile := range matches {
-		statusFile := filepath.Join(filepath.Dir(capFile), "status")
-		capData, err := os.ReadFile(capFile)
-		if err != nil {
+	if batts, _ := readPowerSupplies(p.sysRoot); len(batts) > 0 {
+		return batts, nil
+	}
+
+	return nil, errors.New("no battery data found")
+}
+
+// readPowerSupplies reads every battery's uevent under root/class/power_supply
+// and returns them with the combined battery power in Watts (positive =
+// discharging, negative = charging).
+func readPowerSupplies(root string) ([]BatteryStatus, float64) {
+	dirs, _ := filepath.Glob(filepath.Join(root, "class", "power_supply", "*"))
+	sort.Strings(dirs)
+
+	acOnline := false
+	var batteries []map[string]string
+	for _, dir := range dirs {
+		props := parseUevent(readSysString(filepath.Join(dir, "uevent")))
+		switch props["TYPE"] {
+		case "Mains", "USB":
+			acOnline = acOnline || props["ONLINE"] == "1"
+		case "Battery":
+			// Peripheral batteries (mice, keyboards) report SCOPE=Device.
+			if props["SCOPE"] != "Device" && props["PRESENT"] != "0" {
+				batteries = append(batteries, props)
+			}
+		}
+	}
+
+	var batts []BatteryStatus
+	var totalPower float64
+	for _, props := range batteries {
+		b, watts := batteryFromUevent(props, acOnline)
+		batts = append(batts, b)
+		totalPower += watts
+	}
+	return batts, totalPower
+}
+
+// parseUevent parses POWER_SUPPLY_KEY=value lines into KEY -> value.
+func parseUevent(raw string) map[string]string {
+	props := make(map[string]string)
+	for line := range strings.Lines(raw) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
 			continue
 		}
-		statusData, _ := os.ReadFile(statusFile)
-		percentStr := strings.TrimSpace(string(capData))
-		percent, _ := strconv.ParseFloat(percentStr, 64)
-		status := strings.TrimSpace(string(statusData))
-		if status == "" {
-			status = "Unknown"
+		props[strings.TrimPrefix(key, "POWER_SUPPLY_")] = value
+	}
+	return props
+}
+
+// batteryFromUevent fills a BatteryStatus from one battery's properties.
+// Drivers report either energy (µWh) or charge (µAh) counters, and either
+// power (µW) or current (µA) alongside voltage (µV).
+func batteryFromUevent(props map[string]string, acOnline bool) (BatteryStatus, float64) {
+	num := func(key string) float64 {
+		v, _ := strconv.ParseFloat(props[key], 64)
+		return v
+	}
+	voltage := num("VOLTAGE_NOW") / 1e6
+
+	now, full, design := num("ENERGY_NOW"), num("ENERGY_FULL"), num("ENERGY_FULL_DESIGN")
+	if full == 0 {
+		// Convert charge to energy so time estimates share units with power.
+		now, full, design = num("CHARGE_NOW")*voltage, num("CHARGE_FULL")*voltage, num("CHARGE_FULL_DESIGN")*voltage
+	}
+	watts := num("POWER_NOW") / 1e6
+	if watts == 0 {
+		watts = num("CURRENT_NOW") / 1e6 * voltage
+	}
+	watts = math.Abs(watts) // Some drivers report discharge as negative.
+
+	b := BatteryStatus{
+		Percent:    num("CAPACITY"),
+		Status:     linuxBatteryStatus(props["STATUS"], acOnline),
+		CycleCount: int(num("CYCLE_COUNT")),
+	}
+	if b.Percent == 0 && full > 0 {
+		b.Percent = now / full * 100
+	}
+	if design > 0 && full > 0 {
+		b.Capacity = int(math.Round(min(full/design, 1) * 100))
+	}
+	b.Health = props["HEALTH"]
+	if b.Health == "" && b.Capacity > 0 {
+		b.Health = "Normal"
+		if b.Capacity < 80 {
+			b.Health = "Service Recommended"
 		}
-		batts = append(batts, BatteryStatus{
-			Percent: percent,
-			Status:  status,
-		})
 	}
-	if len(batts) > 0 {
-		return batts, nil
+
+	var signed float64
+	switch b.Status {
+	case "Discharging":
+		signed = watts
+		if watts > 0 && now > 0 {
+			b.TimeLeft = formatBatteryTime(now / 1e6 / watts)
+		}
+	case "Charging":
+		signed = -watts
+		if watts > 0 && full > now {
+			b.TimeLeft = formatBatteryTime((full - now) / 1e6 / watts)
+		}
 	}
+	return b, signed
+}
 
-	return nil, errors.New("no battery data found")
+// linuxBatteryStatus maps sysfs status names onto the pmset vocabulary the
+// power card understands.
+func linuxBatteryStatus(status string, acOnline bool) string {
+	switch status {
+	case "Full":
+		return "Charged"
+	case "Not charging", "Unknown", "":
+		if acOnline {
+			return "AC attached"
+		}
+		if status == "" {
+			return "Unknown"
+		}
+	}
+	return status
+}
+
+// formatBatteryTime renders hours as pmset-style h:mm.
+func formatBatteryTime(hours float64) string {
+	if hours <= 0 || hours > 48 {
+		return ""
+	}
+	minutes := int(math.Round(hours * 60))
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
 }
 
 func parsePMSet(raw string, health string, cycles int, capacity int) []BatteryStatus {
@@ -119,8 +225,8 @@ func parsePMSet(raw string, health string, cycles int, capacity int) []BatterySt
 }
 
 // getCachedPowerData returns condition, cycles, and capacity from cached system_profiler.
-func getCachedPowerData() (health string, cycles int, capacity int) {
-	out := getSystemPowerOutput()
+func getCachedPowerData(ctx context.Context) (health string, cycles int, capacity int) {
+	out := getSystemPowerOutput(ctx)
 	if out == "" {
 		return "", 0, 0
 	}
@@ -148,36 +254,50 @@ func getCachedPowerData() (health string, cycles int, capacity int) {
 	return health, cycles, capacity
 }
 
-func getSystemPowerOutput() string {
-	if runtime.GOOS != "darwin" {
+func getSystemPowerOutput(ctx context.Context) string {
+	p := platformFrom(ctx)
+	if p.goos != "darwin" {
 		return ""
 	}
 
 	now := time.Now()
-	if cachedPower != "" && now.Sub(lastPowerAt) < powerCacheTTL {
-		return cachedPower
+	p.powerMu.Lock()
+	cached, fresh := p.powerOut, p.powerOut != "" && now.Sub(p.powerAt) < powerCacheTTL
+	p.powerMu.Unlock()
+	if fresh {
+		return cached
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	out, err := runCmd(ctx, "system_profiler", "SPPowerDataType")
-	if err == nil {
-		cachedPower = out
-		lastPowerAt = now
+	if err != nil {
+		return cached
 	}
-	return cachedPower
+	p.powerMu.Lock()
+	p.powerOut, p.powerAt = out, now
+	p.powerMu.Unlock()
+	return out
 }
 
-func collectThermal() ThermalStatus {
-	if runtime.GOOS != "darwin" {
+// collectThermal summarizes temperatures, fans and power. On Linux it is
+// derived from sensors, the readings collectSensors took this refresh.
+func collectThermal(ctx context.Context, sensors []SensorReading) ThermalStatus {
+	p := platformFrom(ctx)
+	if p.goos == "linux" {
+		thermal := thermalFromSensors(sensors)
+		_, thermal.BatteryPower = readPowerSupplies(p.sysRoot)
+		return thermal
+	}
+	if p.goos != "darwin" {
 		return ThermalStatus{}
 	}
 
 	var thermal ThermalStatus
 
 	// Fan info from cached system_profiler.
-	out := getSystemPowerOutput()
+	out := getSystemPowerOutput(ctx)
 	if out != "" {
 		for line := range strings.Lines(out) {
 			lower := strings.ToLower(line)
@@ -192,7 +312,7 @@ func collectThermal() ThermalStatus {
 	}
 
 	// Power metrics from ioreg (fast, real-time).
-	ctxPower, cancelPower := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	ctxPower, cancelPower := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancelPower()
 	if out, err := runCmd(ctxPower, "ioreg", "-rn", "AppleSmartBattery"); err == nil {
 		for line := range strings.Lines(out) {
@@ -268,7 +388,7 @@ func collectThermal() ThermalStatus {
 
 	// Fallback: thermal level proxy.
 	if thermal.CPUTemp == 0 {
-		ctx2, cancel2 := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		ctx2, cancel2 := context.WithTimeout(ctx, 500*time.Millisecond)
 		defer cancel2()
 		out2, err := runCmd(ctx2, "sysctl", "-n", "machdep.xcpm.cpu_thermal_level")
 		if err == nil {
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadPowerSupplies(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    BatteryStatus
+		power   float64
+	}{
+		{
+			fixture: "discharging",
+			want: BatteryStatus{
+				Percent: 62, Status: "Discharging", TimeLeft: "3:00",
+				Health: "Normal", CycleCount: 412, Capacity: 80,
+			},
+			power: 9.5,
+		},
+		{
+			// Charge counters (µAh) and current instead of power.
+			fixture: "charging",
+			want: BatteryStatus{
+				Percent: 43, Status: "Charging", TimeLeft: "1:00",
+				Health: "Service Recommended", Capacity: 70,
+			},
+			power: -24,
+		},
+		{
+			// Held below full by a charge threshold while on AC.
+			fixture: "plugged",
+			want: BatteryStatus{
+				Percent: 80, Status: "AC attached", Health: "Good", Capacity: 98,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			batts, power := readPowerSupplies(filepath.Join("testdata", "power_supply", tt.fixture))
+			if len(batts) != 1 {
+				t.Fatalf("got %d batteries, want 1 (peripherals skipped): %+v", len(batts), batts)
+			}
+			if batts[0] != tt.want {
+				t.Errorf("battery = %+v, want %+v", batts[0], tt.want)
+			}
+			if power != tt.power {
+				t.Errorf("power = %v W, want %v W", power, tt.power)
+			}
+		})
+	}
+}
+
+func TestReadPowerSuppliesWithoutBattery(t *testing.T) {
+	if batts, power := readPowerSupplies(t.TempDir()); len(batts) != 0 || power != 0 {
+		t.Errorf("empty sysfs = %+v, %v", batts, power)
+	}
+}
+
+func TestRenderBatteryCardShowsChargeRate(t *testing.T) {
+	card := renderBatteryCard(
+		[]BatteryStatus{{Percent: 43, Status: "Charging", TimeLeft: "1:00"}},
+		ThermalStatus{BatteryPower: -24},
+	)
+	var status string
+	for _, line := range card.lines {
+		if strings.Contains(stripANSI(line), "Charging") {
+			status = stripANSI(line)
+		}
+	}
+	if !strings.Contains(status, "Charging · 1:00 · 24W") {
+		t.Errorf("status line = %q", status)
+	}
+}
@@ -3,46 +3,33 @@ package main
 import (
 	"context"
 	"errors"
-	"runtime"
 	"strings"
 	"time"
 )
 
 const (
-	bluetoothCacheTTL   = 30 * time.Second
-	bluetoothctlTimeout = 1500 * time.Millisecond
+	bluetoothRefreshInterval = 30 * time.Second
+	bluetoothctlTimeout      = 1500 * time.Millisecond
 )
 
-func (c *Collector) collectBluetooth(now time.Time) []BluetoothDevice {
-	if len(c.lastBT) > 0 && !c.lastBTAt.IsZero() && now.Sub(c.lastBTAt) < bluetoothCacheTTL {
-		return c.lastBT
-	}
-
-	if devs, err := readSystemProfilerBluetooth(); err == nil && len(devs) > 0 {
-		c.lastBTAt = now
-		c.lastBT = devs
+func collectBluetooth(ctx context.Context) []BluetoothDevice {
+	if devs, err := readSystemProfilerBluetooth(ctx); err == nil && len(devs) > 0 {
 		return devs
 	}
 
-	if devs, err := readBluetoothCTLDevices(); err == nil && len(devs) > 0 {
-		c.lastBTAt = now
-		c.lastBT = devs
+	if devs, err := readBluetoothCTLDevices(ctx); err == nil && len(devs) > 0 {
 		return devs
 	}
 
-	c.lastBTAt = now
-	if len(c.lastBT) == 0 {
-		c.lastBT = []BluetoothDevice{{Name: "No Bluetooth info", Connected: false}}
-	}
-	return c.lastBT
+	return []BluetoothDevice{{Name: "No Bluetooth info", Connected: false}}
 }
 
-func readSystemProfilerBluetooth() ([]BluetoothDevice, error) {
-	if runtime.GOOS != "darwin" || !commandExists("system_profiler") {
+func readSystemProfilerBluetooth(ctx context.Context) ([]BluetoothDevice, error) {
+	if platformFrom(ctx).goos != "darwin" || !commandExists(ctx, "system_profiler") {
 		return nil, errors.New("system_profiler unavailable")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), systemProfilerTimeout)
+	ctx, cancel := context.WithTimeout(ctx, systemProfilerTimeout)
 	defer cancel()
 
 	out, err := runCmd(ctx, "system_profiler", "SPBluetoothDataType")
@@ -52,12 +39,12 @@ func readSystemProfilerBluetooth() ([]BluetoothDevice, error) {
 	return parseSPBluetooth(out), nil
 }
 
-func readBluetoothCTLDevices() ([]BluetoothDevice, error) {
-	if !commandExists("bluetoothctl") {
+func readBluetoothCTLDevices(ctx context.Context) ([]BluetoothDevice, error) {
+	if !commandExists(ctx, "bluetoothctl") {
 		return nil, errors.New("bluetoothctl unavailable")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), bluetoothctlTimeout)
+	ctx, cancel := context.WithTimeout(ctx, bluetoothctlTimeout)
 	defer cancel()
 
 	out, err := runCmd(ctx, "bluetoothctl", "info")
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	topCgroupCount = 5
+	cgroupMaxDepth = 2 // e.g. system.slice/nginx.service
+)
+
+// showCgroupCard enables the per-cgroup breakdown card (--cgroups).
+var showCgroupCard bool
+
+// cgroupSample holds cumulative counters for rate calculation.
+type cgroupSample struct {
+	cpuUsec uint64
+	rbytes  uint64
+	wbytes  uint64
+}
+
+// cgroupCounters is one cgroup's raw reading.
+type cgroupCounters struct {
+	cgroupSample
+	memory uint64
+}
+
+func (c *Collector) collectCgroup(ctx context.Context, now time.Time) (CgroupStatus, error) {
+	p := platformFrom(ctx)
+	if p.goos != "linux" {
+		return CgroupStatus{}, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return CgroupStatus{}, err
+	}
+	return c.readCgroup(p.procRoot, p.cgroupRoot, now), nil
+}
+
+// readCgroup reports the limits and usage of this process's cgroup, reading
+// /proc/self/cgroup under proc and the hierarchy under root.
+func (c *Collector) readCgroup(proc, root string, now time.Time) CgroupStatus {
+	unified, controllers := parseProcCgroup(readSysString(filepath.Join(proc, "self", "cgroup")))
+
+	var (
+		status   CgroupStatus
+		counters map[string]cgroupCounters
+	)
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+		status, counters = readCgroupV2(root, unified)
+	} else if len(controllers) > 0 {
+		status, counters = readCgroupV1(root, controllers)
+	} else {
+		return CgroupStatus{}
+	}
+
+	// Turn cumulative counters into rates against the previous refresh.
+	elapsed := now.Sub(c.lastCgroupAt).Seconds()
+	rates := func(name string, cur cgroupCounters) (cpu, read, write float64) {
+		prev, ok := c.prevCgroups[name]
+		if !ok || c.lastCgroupAt.IsZero() || elapsed <= 0 {
+			return 0, 0, 0
+		}
+		if cur.cpuUsec >= prev.cpuUsec {
+			cpu = float64(cur.cpuUsec-prev.cpuUsec) / 1e6 / elapsed
+		}
+		return cpu, counterRate(prev.rbytes, cur.rbytes, elapsed), counterRate(prev.wbytes, cur.wbytes, elapsed)
+	}
+
+	self := counters[""]
+	status.MemoryUsed = self.memory
+	status.CPUUsage, status.ReadRate, status.WriteRate = rates("", self)
+	for name, cur := range counters {
+		if name == "" {
+			continue
+		}
+		child := CgroupUsage{Name: name, MemoryUsed: cur.memory}
+		child.CPUUsage, child.ReadRate, child.WriteRate = rates(name, cur)
+		status.Children = append(status.Children, child)
+	}
+	sort.Slice(status.Children, func(i, j int) bool {
+		a, b := status.Children[i], status.Children[j]
+		if a.CPUUsage != b.CPUUsage {
+			return a.CPUUsage > b.CPUUsage
+		}
+		if a.MemoryUsed != b.MemoryUsed {
+			return a.MemoryUsed > b.MemoryUsed
+		}
+		return a.Name < b.Name
+	})
+	if len(status.Children) > topCgroupCount {
+		status.Children = status.Children[:topCgroupCount]
+	}
+
+	c.prevCgroups = make(map[string]cgroupSample, len(counters))
+	for name, cur := range counters {
+		c.prevCgroups[name] = cur.cgroupSample
+	}
+	c.lastCgroupAt = now
+	return status
+}
+
+// parseProcCgroup parses /proc/self/cgroup into the v2 path ("0::/path") and
+// v1 controller paths ("4:memory:/docker/abc").
+func parseProcCgroup(raw string) (unified string, controllers map[string]string) {
+	controllers = make(map[string]string)
+	for line := range strings.Lines(raw) {
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			unified = parts[2]
+			continue
+		}
+		for ctrl := range strings.SplitSeq(parts[1], ",") {
+			controllers[ctrl] = parts[2]
+		}
+	}
+	return unified, controllers
+}
+
+// readCgroupV2 reads the unified hierarchy. Limits are the tightest along the
+// path to the root. Counters are keyed by path relative to the root, down to
+// units such as system.slice/nginx.service, with "" for the cgroup itself:
+// processes only live in leaf cgroups, so on a systemd host mole's own
+// cgroup (a session scope or a service) has nothing below it. Inside a
+// cgroup namespace the root is the container's cgroup.
+func readCgroupV2(root, path string) (CgroupStatus, map[string]cgroupCounters) {
+	if path == "" {
+		path = "/"
+	}
+	dir := filepath.Join(root, path)
+	if !dirExists(dir) {
+		// Without a cgroup namespace a container sees the host's path but
+		// has its own cgroup mounted at root.
+		dir = root
+	}
+	status := CgroupStatus{Path: path, Version: 2}
+
+	for d := dir; ; d = filepath.Dir(d) {
+		if quota, period, ok := parseCPUMax(readSysString(filepath.Join(d, "cpu.max"))); ok {
+			status.CPULimit = minLimit(status.CPULimit, quota/period)
+		}
+		if limit, ok := parseLimit(readSysString(filepath.Join(d, "memory.max"))); ok {
+			status.MemoryLimit = uint64(minLimit(float64(status.MemoryLimit), float64(limit)))
+		}
+		if len(d) <= len(root) || d == filepath.Dir(d) {
+			break
+		}
+	}
+
+	counters := map[string]cgroupCounters{"": readCgroupV2Counters(dir)}
+	var walk func(rel string, depth int)
+	walk = func(rel string, depth int) {
+		entries, _ := os.ReadDir(filepath.Join(root, rel))
+		leaf := true
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			leaf = false
+			child := filepath.Join(rel, e.Name())
+			if depth+1 >= cgroupMaxDepth {
+				counters[child] = readCgroupV2Counters(filepath.Join(root, child))
+				continue
+			}
+			walk(child, depth+1)
+		}
+		if leaf && rel != "" {
+			counters[rel] = readCgroupV2Counters(filepath.Join(root, rel))
+		}
+	}
+	walk("", 0)
+	return status, counters
+}
+
+func readCgroupV2Counters(dir string) cgroupCounters {
+	var cur cgroupCounters
+	if v, ok := readSysInt(filepath.Join(dir, "memory.current")); ok {
+		cur.memory = uint64(v)
+	}
+	for line := range strings.Lines(readSysString(filepath.Join(dir, "cpu.stat"))) {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "usage_usec "); ok {
+			cur.cpuUsec, _ = strconv.ParseUint(value, 10, 64)
+		}
+	}
+	// io.stat: "8:0 rbytes=1024 wbytes=2048 rios=1 wios=2 dbytes=0 dios=0".
+	for line := range strings.Lines(readSysString(filepath.Join(dir, "io.stat"))) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, _ := strings.Cut(field, "=")
+			n, _ := strconv.ParseUint(value, 10, 64)
+			switch key {
+			case "rbytes":
+				cur.rbytes += n
+			case "wbytes":
+				cur.wbytes += n
+			}
+		}
+	}
+	return cur
+}
+
+// readCgroupV1 reads the per-controller hierarchies. Child breakdown is only
+// available on v2.
+func readCgroupV1(root string, controllers map[string]string) (CgroupStatus, map[string]cgroupCounters) {
+	status := CgroupStatus{Path: controllers["memory"], Version: 1}
+	if status.Path == "" {
+		status.Path = controllers["cpu"]
+	}
+	var cur cgroupCounters
+
+	if path, ok := controllers["cpu"]; ok {
+		dir := v1Dir(root, path, "cpu,cpuacct", "cpu")
+		quota, okQuota := readSysInt(filepath.Join(dir, "cpu.cfs_quota_us"))
+		period, okPeriod := readSysInt(filepath.Join(dir, "cpu.cfs_period_us"))
+		if okQuota && okPeriod && quota > 0 && period > 0 {
+			status.CPULimit = float64(quota) / float64(period)
+		}
+	}
+	if path, ok := controllers["cpuacct"]; ok {
+		dir := v1Dir(root, path, "cpu,cpuacct", "cpuacct")
+		if ns, ok := readSysInt(filepath.Join(dir, "cpuacct.usage")); ok {
+			cur.cpuUsec = uint64(ns) / 1000
+		}
+	}
+	if path, ok := controllers["memory"]; ok {
+		dir := v1Dir(root, path, "memory")
+		if limit, ok := parseLimit(readSysString(filepath.Join(dir, "memory.limit_in_bytes"))); ok {
+			status.MemoryLimit = limit
+		}
+		if v, ok := readSysInt(filepath.Join(dir, "memory.usage_in_bytes")); ok {
+			cur.memory = uint64(v)
+		}
+	}
+	if path, ok := controllers["blkio"]; ok {
+		dir := v1Dir(root, path, "blkio")
+		// "8:0 Read 1024" per device and operation, then "Total 4096".
+		for line := range strings.Lines(readSysString(filepath.Join(dir, "blkio.throttle.io_service_bytes"))) {
+			fields := strings.Fields(line)
+			if len(fields) != 3 {
+				continue
+			}
+			n, _ := strconv.ParseUint(fields[2], 10, 64)
+			switch fields[1] {
+			case "Read":
+				cur.rbytes += n
+			case "Write":
+				cur.wbytes += n
+			}
+		}
+	}
+	return status, map[string]cgroupCounters{"": cur}
+}
+
+// v1Dir returns the controller directory for path. Inside a container the
+// mount already is the container's cgroup, so fall back to the mount itself.
+func v1Dir(root, path string, mounts ...string) string {
+	for _, m := range mounts {
+		if dir := filepath.Join(root, m, path); dirExists(dir) {
+			return dir
+		}
+	}
+	for _, m := range mounts {
+		if dir := filepath.Join(root, m); dirExists(dir) {
+			return dir
+		}
+	}
+	return filepath.Join(root, mounts[0], path)
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// parseCPUMax parses cpu.max ("200000 100000"); ok is false when unlimited.
+func parseCPUMax(raw string) (quota, period float64, ok bool) {
+	fields := strings.Fields(raw)
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0, 0, false
+	}
+	quota, errQ := strconv.ParseFloat(fields[0], 64)
+	period, errP := strconv.ParseFloat(fields[1], 64)
+	if errQ != nil || errP != nil || quota <= 0 || period <= 0 {
+		return 0, 0, false
+	}
+	return quota, period, true
+}
+
+// parseLimit parses a byte limit; "max" and v1's near-MaxInt64 sentinel mean
+// unlimited.
+func parseLimit(raw string) (uint64, bool) {
+	v, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || v == 0 || v >= math.MaxInt64/2 {
+		return 0, false
+	}
+	return v, true
+}
+
+// minLimit returns the tighter of two limits where 0 means unlimited.
+func minLimit(a, b float64) float64 {
+	if a == 0 {
+		return b
+	}
+	return min(a, b)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tw93/mole/internal/units"
+)
+
+func cgroupFixture(version string) (proc, root string) {
+	dir := filepath.Join("testdata", "cgroup", version)
+	return filepath.Join(dir, "proc"), filepath.Join(dir, "fs")
+}
+
+func TestReadCgroupV2(t *testing.T) {
+	proc, root := cgroupFixture("v2")
+	now := time.Now()
+	c := &Collector{}
+
+	cg := c.readCgroup(proc, root, now)
+	if cg.Version != 2 || cg.Path != "/system.slice/app.service" {
+		t.Fatalf("cgroup = %+v", cg)
+	}
+	if cg.CPULimit != 1.5 {
+		t.Errorf("CPULimit = %v, want 1.5 from the service's cpu.max", cg.CPULimit)
+	}
+	if cg.MemoryLimit != 4<<30 {
+		t.Errorf("MemoryLimit = %d, want the parent slice's 4GiB", cg.MemoryLimit)
+	}
+	if cg.MemoryUsed != 1<<30 || cg.CPUUsage != 0 {
+		t.Errorf("usage = %d bytes, %v cores; want 1GiB and no rate on first sample", cg.MemoryUsed, cg.CPUUsage)
+	}
+
+	var names []string
+	for _, child := range cg.Children {
+		names = append(names, child.Name)
+	}
+	// The service is a leaf, so the breakdown walks the slices from the
+	// root, busiest memory first before rates exist. Session scopes are
+	// counted in their user's slice.
+	want := "system.slice/app.service,user.slice/user-1000.slice,system.slice/nginx.service,system.slice/systemd-journald.service,init.scope"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("children = %s, want %s", got, want)
+	}
+	if card := strings.Join(renderCgroupCard(cg).lines, "\n"); !strings.Contains(card, "nginx.service ") {
+		t.Errorf("card should name units without their slice:\n%s", card)
+	}
+
+	// One second later the service used one core and moved 1MB each way.
+	c.prevCgroups[""] = cgroupSample{cpuUsec: 4000000, rbytes: 1 << 20, wbytes: 1 << 20}
+	c.lastCgroupAt = now.Add(-time.Second)
+	cg = c.readCgroup(proc, root, now)
+	if cg.CPUUsage != 1 || cg.ReadRate != 1 || cg.WriteRate != 1 {
+		t.Errorf("rates = %v cores, %v/%v MB/s; want 1, 1/1", cg.CPUUsage, cg.ReadRate, cg.WriteRate)
+	}
+}
+
+func TestReadCgroupV2InsideNamespace(t *testing.T) {
+	// A cgroup namespace shows the container's cgroup as the root.
+	proc, root := t.TempDir(), t.TempDir()
+	for path, data := range map[string]string{
+		filepath.Join(proc, "self", "cgroup"):            "0::/\n",
+		filepath.Join(root, "cgroup.controllers"):        "cpu io memory\n",
+		filepath.Join(root, "memory.current"):            "104857600\n",
+		filepath.Join(root, "app", "memory.current"):     "73400320\n",
+		filepath.Join(root, "sidecar", "memory.current"): "31457280\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cg := (&Collector{}).readCgroup(proc, root, time.Now())
+	if cg.MemoryUsed != 100<<20 || len(cg.Children) != 2 || cg.Children[0].Name != "app" || cg.Children[1].Name != "sidecar" {
+		t.Errorf("cgroup = %+v", cg)
+	}
+}
+
+func TestReadCgroupV1InsideContainer(t *testing.T) {
+	// The container's cgroup is mounted at the controller root, not under
+	// the /docker/<id> path the kernel reports.
+	proc, root := cgroupFixture("v1")
+	c := &Collector{}
+	now := time.Now()
+	cg := c.readCgroup(proc, root, now)
+
+	want := CgroupStatus{Path: "/docker/4f1c", Version: 1, CPULimit: 0.5, MemoryLimit: 512 << 20, MemoryUsed: 256 << 20}
+	if cg.Path != want.Path || cg.Version != want.Version || cg.CPULimit != want.CPULimit ||
+		cg.MemoryLimit != want.MemoryLimit || cg.MemoryUsed != want.MemoryUsed || len(cg.Children) != 0 {
+		t.Errorf("cgroup = %+v, want %+v", cg, want)
+	}
+	if prev := c.prevCgroups[""]; prev.cpuUsec != 2000000 || prev.rbytes != 4096 || prev.wbytes != 8192 {
+		t.Errorf("counters = %+v", prev)
+	}
+}
+
+func TestReadCgroupWithoutCgroupfs(t *testing.T) {
+	if cg := (&Collector{}).readCgroup(t.TempDir(), t.TempDir(), time.Now()); cg.Version != 0 {
+		t.Errorf("cgroup = %+v, want none", cg)
+	}
+}
+
+func TestParseCgroupLimits(t *testing.T) {
+	if _, _, ok := parseCPUMax("max 100000"); ok {
+		t.Error("cpu.max max should be unlimited")
+	}
+	if q, p, ok := parseCPUMax("250000 100000"); !ok || q/p != 2.5 {
+		t.Errorf("parseCPUMax = %v/%v, %v", q, p, ok)
+	}
+	for _, raw := range []string{"max", "9223372036854771712", "0", ""} {
+		if _, ok := parseLimit(raw); ok {
+			t.Errorf("parseLimit(%q) should be unlimited", raw)
+		}
+	}
+	if v, ok := parseLimit("536870912"); !ok || v != 512<<20 {
+		t.Errorf("parseLimit = %d, %v", v, ok)
+	}
+}
+
+func TestCardsShowCgroupLimits(t *testing.T) {
+	cg := CgroupStatus{Version: 2, CPULimit: 2, CPUUsage: 1, MemoryLimit: 4 << 30, MemoryUsed: 1 << 30}
+
+	cpu := stripANSI(strings.Join(renderCPUCard(CPUStatus{LogicalCPU: 8}, ThermalStatus{}, cg).lines, "\n"))
+	if !strings.Contains(cpu, "50.0% of 2.0 cores") {
+		t.Errorf("CPU card missing limit:\n%s", cpu)
+	}
+	mem := stripANSI(strings.Join(renderMemoryCard(MemoryStatus{Total: 16 << 30}, cg).lines, "\n"))
+	if !strings.Contains(mem, "Limit") || !strings.Contains(mem, "25.0%") {
+		t.Errorf("memory card missing limit:\n%s", mem)
+	}
+
+	// Limits at or above the host's capacity aren't worth a line.
+	loose := CgroupStatus{Version: 2, CPULimit: 16, MemoryLimit: 32 << 30}
+	if lines := renderCPUCard(CPUStatus{LogicalCPU: 8}, ThermalStatus{}, loose).lines; strings.Contains(stripANSI(strings.Join(lines, "\n")), "Limit") {
+		t.Error("CPU limit above core count shown")
+	}
+	if lines := renderMemoryCard(MemoryStatus{Total: 16 << 30}, loose).lines; strings.Contains(stripANSI(strings.Join(lines, "\n")), "Limit") {
+		t.Error("memory limit above RAM shown")
+	}
+}
+
+func TestCgroupCardRateUnit(t *testing.T) {
+	saved := unitFmt
+	t.Cleanup(func() { unitFmt = saved })
+	cg := CgroupStatus{Version: 2, Path: "/", ReadRate: 2, WriteRate: 0.5}
+	for _, tt := range []struct {
+		si   bool
+		want string
+	}{
+		{false, "IO     R 2.0 · W 0.5 MiB/s"},
+		{true, "IO     R 2.1 · W 0.5 MB/s"},
+	} {
+		unitFmt = units.Formatter{SI: tt.si}
+		if lines := renderCgroupCard(cg).lines; lines[len(lines)-1] != tt.want {
+			t.Errorf("SI %v: IO row = %q, want %q", tt.si, lines[len(lines)-1], tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+const (
+	connInterval = 5 * time.Second
+	connTimeout  = 5 * time.Second
+	topConnCount = 5
+)
+
+// collectConnections summarizes this machine's TCP and UDP sockets.
+func collectConnections(ctx context.Context) (ConnectionStatus, error) {
+	conns, err := platformFrom(ctx).connections(ctx)
+	if err != nil {
+		return ConnectionStatus{}, err
+	}
+	names := make(map[int32]string)
+	nameOf := func(pid int32) string {
+		if pid <= 0 {
+			return ""
+		}
+		if name, ok := names[pid]; ok {
+			return name
+		}
+		var name string
+		if p, err := platformFrom(ctx).process(ctx, pid); err == nil {
+			name, _ = p.NameWithContext(ctx)
+		}
+		names[pid] = name
+		return name
+	}
+	return summarizeConnections(conns, nameOf), nil
+}
+
+// summarizeConnections groups sockets into listeners and established
+// connections counted per remote host and per process.
+func summarizeConnections(conns []net.ConnectionStat, nameOf func(int32) string) ConnectionStatus {
+	var (
+		status    ConnectionStatus
+		seen      = make(map[string]bool)
+		byRemote  = make(map[string]int)
+		byProcess = make(map[string]int)
+	)
+	for _, c := range conns {
+		proto := connProto(c)
+		switch {
+		case c.Status == "LISTEN" || (proto == "udp" && c.Raddr.Port == 0):
+			// IPv4 and IPv6 sockets for the same service show up twice.
+			key := fmt.Sprintf("%s/%d/%d", proto, c.Laddr.Port, c.Pid)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			status.Listening = append(status.Listening, ListeningSocket{
+				Proto:   proto,
+				Address: listenAddress(c.Laddr.IP),
+				Port:    c.Laddr.Port,
+				PID:     c.Pid,
+				Process: nameOf(c.Pid),
+			})
+		case c.Status == "ESTABLISHED":
+			status.Established++
+			byRemote[c.Raddr.IP]++
+			name := nameOf(c.Pid)
+			if name == "" {
+				name = "unknown"
+			}
+			byProcess[name]++
+		case c.Status == "TIME_WAIT":
+			status.TimeWait++
+		case c.Status == "CLOSE_WAIT":
+			status.CloseWait++
+		}
+	}
+
+	sort.Slice(status.Listening, func(i, j int) bool {
+		a, b := status.Listening[i], status.Listening[j]
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		return a.Proto < b.Proto
+	})
+	status.ByRemote = topConnectionCounts(byRemote, topConnCount)
+	status.ByProcess = topConnectionCounts(byProcess, topConnCount)
+	return status
+}
+
+func connProto(c net.ConnectionStat) string {
+	// SOCK_DGRAM is 2 on every supported platform.
+	if c.Type == 2 {
+		return "udp"
+	}
+	return "tcp"
+}
+
+// listenAddress shows wildcard binds as "*" so loopback-only services stand out.
+func listenAddress(ip string) string {
+	switch ip {
+	case "", "0.0.0.0", "::", "*":
+		return "*"
+	}
+	return ip
+}
+
+func topConnectionCounts(counts map[string]int, n int) []ConnectionCount {
+	out := make([]ConnectionCount, 0, len(counts))
+	for name, count := range counts {
+		out = append(out, ConnectionCount{Name: name, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	gnet "github.com/shirou/gopsutil/v4/net"
+)
+
+func TestSummarizeConnections(t *testing.T) {
+	addr := func(ip string, port uint32) gnet.Addr { return gnet.Addr{IP: ip, Port: port} }
+	conns := []gnet.ConnectionStat{
+		{Type: 1, Status: "LISTEN", Laddr: addr("0.0.0.0", 5432), Pid: 10},
+		{Type: 1, Status: "LISTEN", Laddr: addr("::", 5432), Pid: 10},
+		{Type: 1, Status: "LISTEN", Laddr: addr("127.0.0.1", 3000), Pid: 20},
+		{Type: 2, Status: "NONE", Laddr: addr("0.0.0.0", 53), Raddr: addr("0.0.0.0", 0), Pid: 30},
+		{Type: 2, Status: "NONE", Laddr: addr("10.0.0.5", 40000), Raddr: addr("1.1.1.1", 53), Pid: 30},
+		{Type: 1, Status: "ESTABLISHED", Laddr: addr("10.0.0.5", 50001), Raddr: addr("10.0.0.9", 5432), Pid: 20},
+		{Type: 1, Status: "ESTABLISHED", Laddr: addr("10.0.0.5", 50002), Raddr: addr("10.0.0.9", 5432), Pid: 20},
+		{Type: 1, Status: "ESTABLISHED", Laddr: addr("10.0.0.5", 50003), Raddr: addr("140.82.112.3", 443)},
+		{Type: 1, Status: "CLOSE_WAIT", Laddr: addr("10.0.0.5", 50004), Raddr: addr("10.0.0.9", 5432), Pid: 20},
+		{Type: 1, Status: "TIME_WAIT", Laddr: addr("10.0.0.5", 50005), Raddr: addr("10.0.0.9", 5432)},
+	}
+	names := map[int32]string{10: "postgres", 20: "node", 30: "dnsmasq"}
+
+	got := summarizeConnections(conns, func(pid int32) string { return names[pid] })
+
+	var listening []string
+	for _, l := range got.Listening {
+		listening = append(listening, fmt.Sprintf("%s %s:%d %s", l.Proto, l.Address, l.Port, l.Process))
+	}
+	if s := strings.Join(listening, ", "); s != "udp *:53 dnsmasq, tcp 127.0.0.1:3000 node, tcp *:5432 postgres" {
+		t.Errorf("listening = %s", s)
+	}
+	if got.Established != 3 || got.CloseWait != 1 || got.TimeWait != 1 {
+		t.Errorf("counts = %d established, %d close-wait, %d time-wait", got.Established, got.CloseWait, got.TimeWait)
+	}
+	if len(got.ByRemote) != 2 || got.ByRemote[0] != (ConnectionCount{Name: "10.0.0.9", Count: 2}) {
+		t.Errorf("ByRemote = %+v", got.ByRemote)
+	}
+	if len(got.ByProcess) != 2 || got.ByProcess[0] != (ConnectionCount{Name: "node", Count: 2}) || got.ByProcess[1].Name != "unknown" {
+		t.Errorf("ByProcess = %+v", got.ByProcess)
+	}
+}
+
+func TestCollectConnectionsSeesLocalListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	got, err := collectConnections(context.Background())
+	if err != nil {
+		t.Skipf("connections unavailable: %v", err)
+	}
+	for _, l := range got.Listening {
+		if l.Proto == "tcp" && l.Port == uint32(port) && l.Address == "127.0.0.1" {
+			return
+		}
+	}
+	t.Errorf("listener on 127.0.0.1:%d not found in %+v", port, got.Listening)
+}
+
+func TestConnectionsCard(t *testing.T) {
+	conns := ConnectionStatus{
+		Listening: []ListeningSocket{
+			{Proto: "tcp", Address: "*", Port: 22, PID: 1, Process: "sshd"},
+			{Proto: "tcp", Address: "127.0.0.1", Port: 3000, PID: 4242, Process: "node"},
+			{Proto: "tcp", Address: "*", Port: 5432},
+			{Proto: "udp", Address: "*", Port: 53, Process: "dnsmasq"},
+			{Proto: "udp", Address: "*", Port: 5353, Process: "avahi-daemon"},
+		},
+		Established: 12,
+		CloseWait:   40,
+		ByProcess:   []ConnectionCount{{Name: "node", Count: 9}, {Name: "ssh", Count: 3}},
+	}
+	out := stripANSI(strings.Join(renderConnectionsCard(conns, listenCardRows).lines, "\n"))
+	for _, want := range []string{"tcp 127.0.0.1:3000   node (4242)", "tcp *:5432           -", "+1 more listening", "Estab  12 · 40 close-wait", "Procs  node ×9 · ssh ×3"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("card missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Peers") {
+		t.Errorf("empty peers line shown:\n%s", out)
+	}
+}
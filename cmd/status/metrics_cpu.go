@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"errors"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -17,13 +18,14 @@ const (
 	cpuSampleInterval = 200 * time.Millisecond
 )
 
-func collectCPU() (CPUStatus, error) {
-	counts, countsErr := cpu.Counts(false)
+func (c *Collector) collectCPU(ctx context.Context) (CPUStatus, error) {
+	p := platformFrom(ctx)
+	counts, countsErr := p.cpuCounts(ctx, false)
 	if countsErr != nil || counts == 0 {
 		counts = runtime.NumCPU()
 	}
 
-	logical, logicalErr := cpu.Counts(true)
+	logical, logicalErr := p.cpuCounts(ctx, true)
 	if logicalErr != nil || logical == 0 {
 		logical = runtime.NumCPU()
 	}
@@ -31,14 +33,43 @@ func collectCPU() (CPUStatus, error) {
 		logical = 1
 	}
 
-	// Two-call pattern for more reliable CPU usage.
-	warmUpCPU()
-	time.Sleep(cpuSampleInterval)
-	percents, err := cpu.Percent(0, true)
+	// Usage, per-core usage and the time split all cover the interval since
+	// the previous collection. The first has nothing to compare against, so
+	// it takes a short sample.
+	if c.prevCoreTimes == nil {
+		if cores, err := p.cpuTimes(ctx, true); err == nil && len(cores) > 0 {
+			c.prevCoreTimes = cores
+			if total, err := p.cpuTimes(ctx, false); err == nil && len(total) > 0 {
+				c.prevCPUTimes = total[0]
+			}
+			select {
+			case <-ctx.Done():
+				return CPUStatus{}, ctx.Err()
+			case <-time.After(cpuSampleInterval):
+			}
+		}
+	}
+	cores, err := p.cpuTimes(ctx, true)
+	var percents []float64
+	if err == nil && len(cores) == len(c.prevCoreTimes) {
+		percents = make([]float64, len(cores))
+		for i := range cores {
+			percents[i] = cpuBusy(c.prevCoreTimes[i], cores[i])
+		}
+	}
+	if err == nil && len(cores) > 0 {
+		c.prevCoreTimes = cores
+	}
+	var times CPUTimes
+	if cur, err := p.cpuTimes(ctx, false); err == nil && len(cur) > 0 {
+		times = cpuTimeShares(c.prevCPUTimes, cur[0])
+		c.prevCPUTimes = cur[0]
+	}
+
 	var totalPercent float64
 	perCoreEstimated := false
-	if err != nil || len(percents) == 0 {
-		fallbackUsage, fallbackPerCore, fallbackErr := fallbackCPUUtilization(logical)
+	if len(percents) == 0 {
+		fallbackUsage, fallbackPerCore, fallbackErr := fallbackCPUUtilization(ctx, logical)
 		if fallbackErr != nil {
 			if err != nil {
 				return CPUStatus{}, err
@@ -55,19 +86,31 @@ func collectCPU() (CPUStatus, error) {
 		totalPercent /= float64(len(percents))
 	}
 
-	loadStats, loadErr := load.Avg()
+	loadStats, loadErr := p.loadAvg(ctx)
 	var loadAvg load.AvgStat
 	if loadStats != nil {
 		loadAvg = *loadStats
 	}
 	if loadErr != nil || isZeroLoad(loadAvg) {
-		if fallback, err := fallbackLoadAvgFromUptime(); err == nil {
+		if fallback, err := fallbackLoadAvgFromUptime(ctx); err == nil {
 			loadAvg = fallback
 		}
 	}
 
 	// P/E core counts for Apple Silicon.
-	pCores, eCores := getCoreTopology()
+	pCores, eCores := getCoreTopology(ctx)
+	var pUsage, eUsage float64
+	if !perCoreEstimated {
+		pUsage, eUsage = coreClassUsage(percents, pCores, eCores)
+	}
+
+	psi, _ := readPSI(p.procRoot, "cpu")
+	freq, maxFreq := readCPUFreq(ctx)
+
+	var history [][]float64
+	if !perCoreEstimated {
+		history = c.recordCoreHistory(percents)
+	}
 
 	return CPUStatus{
 		Usage:            totalPercent,
@@ -80,34 +123,154 @@ func collectCPU() (CPUStatus, error) {
 		LogicalCPU:       logical,
 		PCoreCount:       pCores,
 		ECoreCount:       eCores,
+		PCoreUsage:       pUsage,
+		ECoreUsage:       eUsage,
+		Stall:            psi.Some10,
+		Times:            times,
+		FreqMHz:          freq,
+		MaxFreqMHz:       maxFreq,
+		PerCoreHistory:   history,
 	}, nil
 }
 
+// recordCoreHistory adds one sample per core and returns every core's
+// history. The history restarts if the core count changes, e.g. CPU hotplug.
+func (c *Collector) recordCoreHistory(percents []float64) [][]float64 {
+	if len(c.coreHistory) != len(percents) {
+		c.coreHistory = make([]*RingBuffer, len(percents))
+		for i := range c.coreHistory {
+			c.coreHistory[i] = NewRingBuffer(cpuHistorySize)
+		}
+	}
+	history := make([][]float64, len(percents))
+	for i, v := range percents {
+		c.coreHistory[i].Add(v)
+		history[i] = c.coreHistory[i].Slice()
+	}
+	return history
+}
+
+// coreClassUsage averages per-core usage over performance and efficiency
+// cores. macOS numbers the efficiency cores first.
+func coreClassUsage(perCore []float64, pCores, eCores int) (pUsage, eUsage float64) {
+	if pCores == 0 || eCores == 0 || len(perCore) != pCores+eCores {
+		return 0, 0
+	}
+	for _, v := range perCore[:eCores] {
+		eUsage += v
+	}
+	for _, v := range perCore[eCores:] {
+		pUsage += v
+	}
+	return pUsage / float64(pCores), eUsage / float64(eCores)
+}
+
+// cpuTimeShares converts two cumulative samples into the percentage of time
+// spent in each state between them. The first sample has nothing to compare
+// against and reports zeros.
+func cpuTimeShares(prev, cur cpu.TimesStat) CPUTimes {
+	sum := func(t cpu.TimesStat) float64 {
+		// Guest time is already counted in user time.
+		return t.User + t.Nice + t.System + t.Iowait + t.Irq + t.Softirq + t.Steal + t.Idle
+	}
+	total := sum(cur) - sum(prev)
+	if sum(prev) == 0 || total <= 0 {
+		return CPUTimes{}
+	}
+	share := func(prev, cur float64) float64 {
+		return max(cur-prev, 0) / total * 100
+	}
+	return CPUTimes{
+		User:   share(prev.User+prev.Nice, cur.User+cur.Nice),
+		System: share(prev.System, cur.System),
+		IOWait: share(prev.Iowait, cur.Iowait),
+		IRQ:    share(prev.Irq+prev.Softirq, cur.Irq+cur.Softirq),
+		Steal:  share(prev.Steal, cur.Steal),
+		Idle:   share(prev.Idle, cur.Idle),
+	}
+}
+
+// cpuBusy is the share of time between two samples not spent idle or
+// waiting for IO, as cpu.Percent reports it.
+func cpuBusy(prev, cur cpu.TimesStat) float64 {
+	shares := cpuTimeShares(prev, cur)
+	if shares == (CPUTimes{}) {
+		return 0
+	}
+	return max(100-shares.Idle-shares.IOWait, 0)
+}
+
+// readCPUFreq returns the current clock averaged over cores and the highest
+// rated clock, in MHz. Linux reads cpufreq from sysfs; Intel Macs report a
+// fixed nominal clock and Apple Silicon none at all.
+func readCPUFreq(ctx context.Context) (cur, maxFreq int) {
+	p := platformFrom(ctx)
+	switch p.goos {
+	case "linux":
+		return readSysfsCPUFreq(p.sysRoot)
+	case "darwin":
+		ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+		out, err := runCmd(ctx, "sysctl", "-n", "hw.cpufrequency", "hw.cpufrequency_max")
+		if err != nil {
+			return 0, 0
+		}
+		var hz []int64
+		for line := range strings.Lines(out) {
+			if v, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64); err == nil {
+				hz = append(hz, v)
+			}
+		}
+		if len(hz) != 2 {
+			return 0, 0
+		}
+		return int(hz[0] / 1e6), int(hz[1] / 1e6)
+	}
+	return 0, 0
+}
+
+// readSysfsCPUFreq reads every cpufreq policy under root, which reports kHz.
+func readSysfsCPUFreq(root string) (cur, maxFreq int) {
+	dirs, _ := filepath.Glob(filepath.Join(root, "devices", "system", "cpu", "cpu[0-9]*", "cpufreq"))
+	var sum, n int64
+	for _, dir := range dirs {
+		if khz, ok := readSysInt(filepath.Join(dir, "scaling_cur_freq")); ok && khz > 0 {
+			sum += khz
+			n++
+		}
+		if khz, ok := readSysInt(filepath.Join(dir, "cpuinfo_max_freq")); ok {
+			maxFreq = max(maxFreq, int(khz/1000))
+		}
+	}
+	if n > 0 {
+		cur = int(sum / n / 1000)
+	}
+	return cur, maxFreq
+}
+
 func isZeroLoad(avg load.AvgStat) bool {
 	return avg.Load1 == 0 && avg.Load5 == 0 && avg.Load15 == 0
 }
 
-var (
-	// Cache for core topology.
-	lastTopologyAt   time.Time
-	cachedP, cachedE int
-	topologyTTL      = 10 * time.Minute
-)
+// topologyTTL is how long P/E core counts are cached.
+const topologyTTL = 10 * time.Minute
 
 // getCoreTopology returns P/E core counts on Apple Silicon.
-func getCoreTopology() (pCores, eCores int) {
-	if runtime.GOOS != "darwin" {
+func getCoreTopology(ctx context.Context) (pCores, eCores int) {
+	p := platformFrom(ctx)
+	if p.goos != "darwin" {
 		return 0, 0
 	}
 
 	now := time.Now()
-	if cachedP > 0 || cachedE > 0 {
-		if now.Sub(lastTopologyAt) < topologyTTL {
-			return cachedP, cachedE
-		}
+	p.topologyMu.Lock()
+	if (p.pCores > 0 || p.eCores > 0) && now.Sub(p.topologyAt) < topologyTTL {
+		defer p.topologyMu.Unlock()
+		return p.pCores, p.eCores
 	}
+	p.topologyMu.Unlock()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 
 	out, err := runCmd(ctx, "sysctl", "-n",
@@ -145,16 +308,17 @@ func getCoreTopology() (pCores, eCores int) {
 		eCores = level1Count
 	}
 
-	cachedP, cachedE = pCores, eCores
-	lastTopologyAt = now
+	p.topologyMu.Lock()
+	p.pCores, p.eCores, p.topologyAt = pCores, eCores, now
+	p.topologyMu.Unlock()
 	return pCores, eCores
 }
 
-func fallbackLoadAvgFromUptime() (load.AvgStat, error) {
-	if !commandExists("uptime") {
+func fallbackLoadAvgFromUptime(ctx context.Context) (load.AvgStat, error) {
+	if !commandExists(ctx, "uptime") {
 		return load.AvgStat{}, errors.New("uptime command unavailable")
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 
 	out, err := runCmd(ctx, "uptime")
@@ -202,7 +366,7 @@ func fallbackLoadAvgFromUptime() (load.AvgStat, error) {
 	}, nil
 }
 
-func fallbackCPUUtilization(logical int) (float64, []float64, error) {
+func fallbackCPUUtilization(ctx context.Context, logical int) (float64, []float64, error) {
 	if logical <= 0 {
 		logical = runtime.NumCPU()
 	}
@@ -210,7 +374,7 @@ func fallbackCPUUtilization(logical int) (float64, []float64, error) {
 		logical = 1
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 
 	out, err := runCmd(ctx, "ps", "-Aceo", "pcpu")
@@ -255,7 +419,3 @@ func fallbackCPUUtilization(logical int) (float64, []float64, error) {
 	}
 	return avg, perCore, nil
 }
-
-func warmUpCPU() {
-	cpu.Percent(0, true) //nolint:errcheck
-}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+func TestCPUTimeShares(t *testing.T) {
+	prev := cpu.TimesStat{User: 100, Nice: 10, System: 50, Iowait: 5, Irq: 1, Softirq: 1, Steal: 3, Idle: 830, Guest: 40}
+	cur := cpu.TimesStat{User: 130, Nice: 10, System: 60, Iowait: 10, Irq: 2, Softirq: 2, Steal: 8, Idle: 878, Guest: 60}
+	want := CPUTimes{User: 30, System: 10, IOWait: 5, IRQ: 2, Steal: 5, Idle: 48}
+	got := cpuTimeShares(prev, cur)
+	if got != want {
+		t.Errorf("shares = %+v, want %+v", got, want)
+	}
+
+	if got := cpuTimeShares(cpu.TimesStat{}, cur); got != (CPUTimes{}) {
+		t.Errorf("first sample = %+v, want zeros", got)
+	}
+}
+
+// busyCPUTimes fakes cpu.Times for cores that are busy percent of the time:
+// each call advances every core by one second.
+func busyCPUTimes(cores int, busy float64) func(context.Context, bool) ([]cpu.TimesStat, error) {
+	seconds := 0.0
+	return func(_ context.Context, perCPU bool) ([]cpu.TimesStat, error) {
+		seconds++
+		core := cpu.TimesStat{User: seconds * busy / 100, Idle: seconds * (100 - busy) / 100}
+		if !perCPU {
+			core.User *= float64(cores)
+			core.Idle *= float64(cores)
+			return []cpu.TimesStat{core}, nil
+		}
+		out := make([]cpu.TimesStat, cores)
+		for i := range out {
+			out[i] = core
+		}
+		return out, nil
+	}
+}
+
+// Usage and the user/system/idle split cover the same interval, so they
+// add up.
+func TestCPUUsageMatchesTimes(t *testing.T) {
+	p := fixturePlatform(t, "linux", "amd64", t.TempDir())
+	ctx := withPlatform(context.Background(), p)
+	c := NewCollector()
+
+	p.cpuTimes = busyCPUTimes(4, 25)
+	first, err := c.collectCPU(ctx)
+	if err != nil || first.Usage != 25 || first.Times.Idle != 75 {
+		t.Fatalf("first sample = %+v, %v", first, err)
+	}
+
+	// Later collections compare against the previous one, without sleeping.
+	start := time.Now()
+	cur, err := c.collectCPU(ctx)
+	if err != nil || time.Since(start) >= cpuSampleInterval {
+		t.Fatalf("collect took %s, %v", time.Since(start), err)
+	}
+	if cur.Usage != 25 || math.Abs(cur.Usage+cur.Times.Idle+cur.Times.IOWait-100) > 1e-9 {
+		t.Errorf("usage %v%% with times %+v", cur.Usage, cur.Times)
+	}
+}
+
+func TestCoreClassUsage(t *testing.T) {
+	// Two efficiency cores first, then four performance cores.
+	perCore := []float64{10, 20, 50, 60, 70, 80}
+	if p, e := coreClassUsage(perCore, 4, 2); p != 65 || e != 15 {
+		t.Errorf("P/E = %v/%v, want 65/15", p, e)
+	}
+	if p, e := coreClassUsage(perCore, 4, 4); p != 0 || e != 0 {
+		t.Errorf("mismatched topology = %v/%v, want zeros", p, e)
+	}
+}
+
+func TestReadSysfsCPUFreq(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"devices/system/cpu/cpu0/cpufreq/scaling_cur_freq": "1800000",
+		"devices/system/cpu/cpu0/cpufreq/cpuinfo_max_freq": "4200000",
+		"devices/system/cpu/cpu1/cpufreq/scaling_cur_freq": "3000000",
+		"devices/system/cpu/cpu1/cpufreq/cpuinfo_max_freq": "4700000",
+		"devices/system/cpu/cpufreq/boost":                 "1",
+	})
+	if cur, maxFreq := readSysfsCPUFreq(root); cur != 2400 || maxFreq != 4700 {
+		t.Errorf("clock = %d/%d MHz, want 2400/4700", cur, maxFreq)
+	}
+	if cur, maxFreq := readSysfsCPUFreq(t.TempDir()); cur != 0 || maxFreq != 0 {
+		t.Errorf("no cpufreq = %d/%d, want zeros", cur, maxFreq)
+	}
+}
+
+func TestRecordCoreHistory(t *testing.T) {
+	c := &Collector{}
+	c.recordCoreHistory([]float64{10, 20})
+	history := c.recordCoreHistory([]float64{30, 40})
+	if len(history) != 2 || !slices.Equal(history[0], []float64{10, 30}) || !slices.Equal(history[1], []float64{20, 40}) {
+		t.Errorf("history = %v", history)
+	}
+
+	// A hotplugged core restarts every history.
+	if history := c.recordCoreHistory([]float64{1, 2, 3}); len(history) != 3 || len(history[0]) != 1 {
+		t.Errorf("history after hotplug = %v", history)
+	}
+}
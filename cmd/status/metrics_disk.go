@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"runtime"
+	"maps"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -22,8 +23,9 @@ var skipDiskMounts = map[string]bool{
 	"/dev":                     true,
 }
 
-func collectDisks() ([]DiskStatus, error) {
-	partitions, err := disk.Partitions(false)
+func collectDisks(ctx context.Context) ([]DiskStatus, error) {
+	p := platformFrom(ctx)
+	partitions, err := p.partitions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +56,7 @@ func collectDisks() ([]DiskStatus, error) {
 		if seenDevice[baseDevice] {
 			continue
 		}
-		usage, err := disk.Usage(part.Mountpoint)
+		usage, err := p.diskUsage(ctx, part.Mountpoint)
 		if err != nil || usage.Total == 0 {
 			continue
 		}
@@ -74,61 +76,67 @@ func collectDisks() ([]DiskStatus, error) {
 			Total:       usage.Total,
 			UsedPercent: usage.UsedPercent,
 			Fstype:      part.Fstype,
+
+			InodesUsed:    usage.InodesUsed,
+			InodesTotal:   usage.InodesTotal,
+			InodesPercent: usage.InodesUsedPercent,
 		})
 		seenDevice[baseDevice] = true
 		seenVolume[volKey] = true
 	}
 
-	annotateDiskTypes(disks)
+	annotateDiskTypes(ctx, disks)
 
 	sort.Slice(disks, func(i, j int) bool {
 		return disks[i].Total > disks[j].Total
 	})
 
-	if len(disks) > 3 {
-		disks = disks[:3]
-	}
-
 	return disks, nil
 }
 
-var (
-	// External disk cache.
-	lastDiskCacheAt time.Time
-	diskTypeCache   = make(map[string]bool)
-	diskCacheTTL    = 2 * time.Minute
-)
+// diskCacheTTL is how long internal/external disk lookups are cached.
+const diskCacheTTL = 2 * time.Minute
 
-func annotateDiskTypes(disks []DiskStatus) {
-	if len(disks) == 0 || runtime.GOOS != "darwin" || !commandExists("diskutil") {
+func annotateDiskTypes(ctx context.Context, disks []DiskStatus) {
+	p := platformFrom(ctx)
+	if len(disks) == 0 || p.goos != "darwin" || !commandExists(ctx, "diskutil") {
 		return
 	}
 
 	now := time.Now()
+	p.diskTypesMu.Lock()
 	// Clear stale cache.
-	if now.Sub(lastDiskCacheAt) > diskCacheTTL {
-		diskTypeCache = make(map[string]bool)
-		lastDiskCacheAt = now
+	if p.diskTypes == nil || now.Sub(p.diskTypesAt) > diskCacheTTL {
+		p.diskTypes = make(map[string]bool)
+		p.diskTypesAt = now
 	}
+	known := maps.Clone(p.diskTypes)
+	p.diskTypesMu.Unlock()
 
+	found := make(map[string]bool)
 	for i := range disks {
 		base := baseDeviceName(disks[i].Device)
 		if base == "" {
 			base = disks[i].Device
 		}
 
-		if val, ok := diskTypeCache[base]; ok {
+		if val, ok := known[base]; ok {
 			disks[i].External = val
 			continue
 		}
 
-		external, err := isExternalDisk(base)
+		external, err := isExternalDisk(ctx, base)
 		if err != nil {
 			external = strings.HasPrefix(disks[i].Mount, "/Volumes/")
 		}
 		disks[i].External = external
-		diskTypeCache[base] = external
+		known[base] = external
+		found[base] = external
 	}
+
+	p.diskTypesMu.Lock()
+	maps.Copy(p.diskTypes, found)
+	p.diskTypesMu.Unlock()
 }
 
 func baseDeviceName(device string) string {
@@ -144,8 +152,8 @@ func baseDeviceName(device string) string {
 	return device
 }
 
-func isExternalDisk(device string) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+func isExternalDisk(ctx context.Context, device string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
 	out, err := runCmd(ctx, "diskutil", "info", device)
@@ -174,20 +182,33 @@ func isExternalDisk(device string) (bool, error) {
 	return external, nil
 }
 
-func (c *Collector) collectDiskIO(now time.Time) DiskIOStatus {
-	counters, err := disk.IOCounters()
+func (c *Collector) collectDiskIO(ctx context.Context, now time.Time) DiskIOStatus {
+	status := c.diskIORates(ctx, now)
+	if psi, ok := readPSI(platformFrom(ctx).procRoot, "io"); ok {
+		status.Stall = psi.Some10
+	}
+	return status
+}
+
+func (c *Collector) diskIORates(ctx context.Context, now time.Time) DiskIOStatus {
+	counters, err := platformFrom(ctx).diskCounters(ctx)
 	if err != nil || len(counters) == 0 {
 		return DiskIOStatus{}
 	}
 
 	var total disk.IOCountersStat
-	for _, v := range counters {
+	devices := make(map[string]disk.IOCountersStat)
+	for name, v := range counters {
 		total.ReadBytes += v.ReadBytes
 		total.WriteBytes += v.WriteBytes
+		if isWholeDisk(platformFrom(ctx), name) {
+			devices[name] = v
+		}
 	}
 
 	if c.lastDiskAt.IsZero() {
 		c.prevDiskIO = total
+		c.prevDevIO = devices
 		c.lastDiskAt = now
 		return DiskIOStatus{}
 	}
@@ -197,18 +218,54 @@ func (c *Collector) collectDiskIO(now time.Time) DiskIOStatus {
 		elapsed = 1
 	}
 
-	readRate := float64(total.ReadBytes-c.prevDiskIO.ReadBytes) / 1024 / 1024 / elapsed
-	writeRate := float64(total.WriteBytes-c.prevDiskIO.WriteBytes) / 1024 / 1024 / elapsed
+	status := DiskIOStatus{
+		ReadRate:  counterRate(c.prevDiskIO.ReadBytes, total.ReadBytes, elapsed),
+		WriteRate: counterRate(c.prevDiskIO.WriteBytes, total.WriteBytes, elapsed),
+		Devices:   deviceIORates(c.prevDevIO, devices, elapsed),
+	}
 
 	c.prevDiskIO = total
+	c.prevDevIO = devices
 	c.lastDiskAt = now
+	return status
+}
 
-	if readRate < 0 {
-		readRate = 0
-	}
-	if writeRate < 0 {
-		writeRate = 0
+// deviceIORates computes per-device throughput, IOPS and latency between two
+// samples, sorted by name. Devices missing from prev are skipped.
+func deviceIORates(prev, cur map[string]disk.IOCountersStat, elapsed float64) []DeviceIOStatus {
+	var out []DeviceIOStatus
+	for name, v := range cur {
+		p, ok := prev[name]
+		if !ok || v.ReadCount < p.ReadCount || v.WriteCount < p.WriteCount {
+			continue // New device or counters reset.
+		}
+		dev := DeviceIOStatus{
+			Name:      name,
+			ReadRate:  counterRate(p.ReadBytes, v.ReadBytes, elapsed),
+			WriteRate: counterRate(p.WriteBytes, v.WriteBytes, elapsed),
+			ReadIOPS:  float64(v.ReadCount-p.ReadCount) / elapsed,
+			WriteIOPS: float64(v.WriteCount-p.WriteCount) / elapsed,
+		}
+		ops := (v.ReadCount - p.ReadCount) + (v.WriteCount - p.WriteCount)
+		curTime, prevTime := v.ReadTime+v.WriteTime, p.ReadTime+p.WriteTime
+		if ops > 0 && curTime >= prevTime {
+			dev.Latency = float64(curTime-prevTime) / float64(ops)
+		}
+		out = append(out, dev)
 	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
 
-	return DiskIOStatus{ReadRate: readRate, WriteRate: writeRate}
+// isWholeDisk reports whether an IO counter belongs to a physical disk rather
+// than a partition or loop/ram device. Linux lists partitions alongside their
+// disks; only whole disks appear under /sys/block.
+func isWholeDisk(p *platform, name string) bool {
+	if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") {
+		return false
+	}
+	if p.goos != "linux" {
+		return true
+	}
+	return dirExists(filepath.Join(p.sysRoot, "block", name))
 }
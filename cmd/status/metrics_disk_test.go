@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+func TestDeviceIORates(t *testing.T) {
+	prev := map[string]disk.IOCountersStat{
+		"sda":     {ReadBytes: 0, WriteBytes: 0, ReadCount: 100, WriteCount: 50, ReadTime: 400, WriteTime: 600},
+		"nvme0n1": {ReadBytes: 1 << 20, ReadCount: 10},
+		"sdb":     {ReadCount: 500},
+	}
+	cur := map[string]disk.IOCountersStat{
+		"sda":     {ReadBytes: 4 << 20, WriteBytes: 2 << 20, ReadCount: 300, WriteCount: 250, ReadTime: 1200, WriteTime: 1400},
+		"nvme0n1": {ReadBytes: 1 << 20, ReadCount: 10},
+		"sdb":     {ReadCount: 3}, // Counters reset after a replug.
+		"sdc":     {ReadCount: 7}, // Appeared since the last sample.
+	}
+
+	got := deviceIORates(prev, cur, 2)
+	if len(got) != 2 || got[0].Name != "nvme0n1" || got[1].Name != "sda" {
+		t.Fatalf("devices = %+v", got)
+	}
+	if idle := got[0]; idle.ReadRate != 0 || idle.ReadIOPS != 0 || idle.Latency != 0 {
+		t.Errorf("idle device = %+v", idle)
+	}
+	// 400 ops over 2s taking 1600ms in total.
+	want := DeviceIOStatus{Name: "sda", ReadRate: 2, WriteRate: 1, ReadIOPS: 100, WriteIOPS: 100, Latency: 4}
+	if got[1] != want {
+		t.Errorf("sda = %+v, want %+v", got[1], want)
+	}
+}
+
+func TestIsWholeDisk(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"block/sda/size":     "976773168",
+		"block/nvme0n1/size": "2000409264",
+	})
+	p := &platform{goos: "linux", sysRoot: root}
+	for _, name := range []string{"loop0", "ram1"} {
+		if isWholeDisk(p, name) {
+			t.Errorf("%s counted as a disk", name)
+		}
+	}
+	for name, want := range map[string]bool{"sda": true, "nvme0n1": true, "sda1": false, "nvme0n1p2": false} {
+		if got := isWholeDisk(p, name); got != want {
+			t.Errorf("isWholeDisk(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -23,11 +22,12 @@ var (
 	gpuIdleResidencyRe   = regexp.MustCompile(`GPU idle residency:\s+([\d.]+)%`)
 )
 
-func (c *Collector) collectGPU(now time.Time) ([]GPUStatus, error) {
-	if runtime.GOOS == "darwin" {
+func (c *Collector) collectGPU(ctx context.Context, now time.Time) ([]GPUStatus, error) {
+	p := platformFrom(ctx)
+	if p.goos == "darwin" {
 		// Static GPU info (cached 10 min).
 		if len(c.cachedGPU) == 0 || c.lastGPUAt.IsZero() || now.Sub(c.lastGPUAt) >= macGPUInfoTTL {
-			if gpus, err := readMacGPUInfo(); err == nil && len(gpus) > 0 {
+			if gpus, err := readMacGPUInfo(ctx); err == nil && len(gpus) > 0 {
 				c.cachedGPU = gpus
 				c.lastGPUAt = now
 			}
@@ -35,7 +35,7 @@ func (c *Collector) collectGPU(now time.Time) ([]GPUStatus, error) {
 
 		// Real-time GPU usage.
 		if len(c.cachedGPU) > 0 {
-			usage := getMacGPUUsage()
+			usage := getMacGPUUsage(ctx)
 			result := make([]GPUStatus, len(c.cachedGPU))
 			copy(result, c.cachedGPU)
 			// Apply usage to first GPU (Apple Silicon).
@@ -46,16 +46,44 @@ func (c *Collector) collectGPU(now time.Time) ([]GPUStatus, error) {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 600*time.Millisecond)
-	defer cancel()
+	var gpus []GPUStatus
+	if p.goos == "linux" {
+		gpus = c.readDRMGPUs(p.sysRoot, now)
+	}
 
-	if !commandExists("nvidia-smi") {
+	if !commandExists(ctx, "nvidia-smi") {
+		if len(gpus) > 0 {
+			return gpus, nil
+		}
 		return []GPUStatus{{
 			Name: "No GPU metrics available",
 			Note: "Install nvidia-smi or use platform-specific metrics",
 		}}, nil
 	}
 
+	nvidia, err := readNvidiaGPUs(ctx)
+	if err != nil {
+		if len(gpus) > 0 {
+			return gpus, nil
+		}
+		return nil, err
+	}
+	gpus = append(gpus, nvidia...)
+
+	if len(gpus) == 0 {
+		return []GPUStatus{{
+			Name: "GPU read failed",
+			Note: "Verify nvidia-smi availability",
+		}}, nil
+	}
+
+	return gpus, nil
+}
+
+func readNvidiaGPUs(ctx context.Context) ([]GPUStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, 600*time.Millisecond)
+	defer cancel()
+
 	out, err := runCmd(ctx, "nvidia-smi", "--query-gpu=utilization.gpu,memory.used,memory.total,name", "--format=csv,noheader,nounits")
 	if err != nil {
 		return nil, err
@@ -79,22 +107,14 @@ func (c *Collector) collectGPU(now time.Time) ([]GPUStatus, error) {
 			MemoryTotal: memTotal,
 		})
 	}
-
-	if len(gpus) == 0 {
-		return []GPUStatus{{
-			Name: "GPU read failed",
-			Note: "Verify nvidia-smi availability",
-		}}, nil
-	}
-
 	return gpus, nil
 }
 
-func readMacGPUInfo() ([]GPUStatus, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), systemProfilerTimeout)
+func readMacGPUInfo(ctx context.Context) ([]GPUStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, systemProfilerTimeout)
 	defer cancel()
 
-	if !commandExists("system_profiler") {
+	if !commandExists(ctx, "system_profiler") {
 		return nil, errors.New("system_profiler unavailable")
 	}
 
@@ -152,8 +172,8 @@ func readMacGPUInfo() ([]GPUStatus, error) {
 }
 
 // getMacGPUUsage reads GPU active residency from powermetrics.
-func getMacGPUUsage() float64 {
-	ctx, cancel := context.WithTimeout(context.Background(), powermetricsTimeout)
+func getMacGPUUsage(ctx context.Context) float64 {
+	ctx, cancel := context.WithTimeout(ctx, powermetricsTimeout)
 	defer cancel()
 
 	// powermetrics may require root.
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// drmCardRe matches primary DRM nodes, not connectors like card0-eDP-1.
+var drmCardRe = regexp.MustCompile(`^card\d+$`)
+
+// drmDrivers names the GPUs readable from sysfs; other drivers are skipped.
+var drmDrivers = map[string]string{
+	"amdgpu": "AMD Radeon",
+	"radeon": "AMD Radeon",
+	"i915":   "Intel Graphics",
+	"xe":     "Intel Graphics",
+}
+
+// gpuIdleSample is a cumulative idle-residency reading for usage deltas.
+type gpuIdleSample struct {
+	idleMs int64
+	at     time.Time
+}
+
+// drmCard is one GPU's raw sysfs reading before usage is derived.
+type drmCard struct {
+	status  GPUStatus
+	idleMs  int64 // Cumulative RC6/gtidle residency, Intel only
+	hasIdle bool
+}
+
+// readDRMGPUs reads AMD and Intel GPUs from root/class/drm. AMD reports busy
+// percent directly; Intel usage is derived from idle residency between calls.
+func (c *Collector) readDRMGPUs(root string, now time.Time) []GPUStatus {
+	dirs, _ := filepath.Glob(filepath.Join(root, "class", "drm", "card*"))
+	sort.Strings(dirs)
+
+	if c.prevGPUIdle == nil {
+		c.prevGPUIdle = make(map[string]gpuIdleSample)
+	}
+	var gpus []GPUStatus
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if !drmCardRe.MatchString(name) {
+			continue
+		}
+		card, ok := readDRMCard(dir)
+		if !ok {
+			continue
+		}
+		if card.hasIdle {
+			card.status.Usage = -1
+			if prev, ok := c.prevGPUIdle[name]; ok {
+				card.status.Usage = idleToBusy(prev, gpuIdleSample{card.idleMs, now})
+			}
+			c.prevGPUIdle[name] = gpuIdleSample{card.idleMs, now}
+		}
+		gpus = append(gpus, card.status)
+	}
+	return gpus
+}
+
+// readDRMCard reads one cardN directory; ok is false for unsupported drivers.
+func readDRMCard(dir string) (drmCard, bool) {
+	device := filepath.Join(dir, "device")
+	driver := parseUevent(readSysString(filepath.Join(device, "uevent")))["DRIVER"]
+	vendor, ok := drmDrivers[driver]
+	if !ok {
+		return drmCard{}, false
+	}
+
+	card := drmCard{status: GPUStatus{Name: vendor, Usage: -1, Note: driver}}
+	s := &card.status
+	if busy, ok := readSysInt(filepath.Join(device, "gpu_busy_percent")); ok {
+		s.Usage = float64(busy)
+	}
+	if used, ok := readSysInt(filepath.Join(device, "mem_info_vram_used")); ok {
+		s.MemoryUsed = float64(used) / (1 << 20)
+	}
+	if total, ok := readSysInt(filepath.Join(device, "mem_info_vram_total")); ok {
+		s.MemoryTotal = float64(total) / (1 << 20)
+	}
+
+	switch driver {
+	case "amdgpu", "radeon":
+		s.FreqMHz, s.MaxFreqMHz = parseDPMClock(readSysString(filepath.Join(device, "pp_dpm_sclk")))
+	case "i915":
+		s.FreqMHz = readSysMHz(filepath.Join(dir, "gt_act_freq_mhz"), filepath.Join(dir, "gt_cur_freq_mhz"))
+		s.MaxFreqMHz = readSysMHz(filepath.Join(dir, "gt_max_freq_mhz"))
+		card.idleMs, card.hasIdle = readSysInt(filepath.Join(dir, "power", "rc6_residency_ms"))
+	case "xe":
+		gt := filepath.Join(device, "tile0", "gt0")
+		s.FreqMHz = readSysMHz(filepath.Join(gt, "freq0", "act_freq"), filepath.Join(gt, "freq0", "cur_freq"))
+		s.MaxFreqMHz = readSysMHz(filepath.Join(gt, "freq0", "max_freq"))
+		card.idleMs, card.hasIdle = readSysInt(filepath.Join(gt, "gtidle", "idle_residency_ms"))
+	}
+
+	hwmons, _ := filepath.Glob(filepath.Join(device, "hwmon", "hwmon*"))
+	sort.Strings(hwmons)
+	for _, hw := range hwmons {
+		if milli, ok := readSysInt(filepath.Join(hw, "temp1_input")); ok && milli > 0 {
+			s.Temp = float64(milli) / 1000
+			break
+		}
+	}
+	return card, true
+}
+
+// idleToBusy converts idle residency growth over wall time into busy percent.
+func idleToBusy(prev, cur gpuIdleSample) float64 {
+	wallMs := cur.at.Sub(prev.at).Milliseconds()
+	idle := cur.idleMs - prev.idleMs
+	if wallMs <= 0 || idle < 0 {
+		return -1
+	}
+	return max(0, min(100, 100*(1-float64(idle)/float64(wallMs))))
+}
+
+// parseDPMClock parses amdgpu pp_dpm_sclk ("1: 1200Mhz *") into the active
+// and highest clock levels.
+func parseDPMClock(raw string) (cur, maxMHz int) {
+	for line := range strings.Lines(raw) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		mhz, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(fields[1]), "mhz"))
+		if err != nil {
+			continue
+		}
+		maxMHz = max(maxMHz, mhz)
+		if len(fields) > 2 && fields[2] == "*" {
+			cur = mhz
+		}
+	}
+	return cur, maxMHz
+}
+
+// readSysMHz returns the first readable value among paths.
+func readSysMHz(paths ...string) int {
+	for _, p := range paths {
+		if v, ok := readSysInt(p); ok && v > 0 {
+			return int(v)
+		}
+	}
+	return 0
+}
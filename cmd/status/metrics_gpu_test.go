@@ -0,0 +1,97 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadDRMGPUsAMD(t *testing.T) {
+	c := &Collector{}
+	gpus := c.readDRMGPUs(filepath.Join("testdata", "drm", "amd"), time.Now())
+	if len(gpus) != 1 {
+		t.Fatalf("got %d GPUs, want the amdgpu card only: %+v", len(gpus), gpus)
+	}
+	want := GPUStatus{
+		Name:        "AMD Radeon",
+		Usage:       37,
+		MemoryUsed:  2048,
+		MemoryTotal: 16368,
+		FreqMHz:     1850,
+		MaxFreqMHz:  2250,
+		Temp:        52,
+		Note:        "amdgpu",
+	}
+	if gpus[0] != want {
+		t.Errorf("GPU = %+v, want %+v", gpus[0], want)
+	}
+}
+
+func TestReadDRMGPUsIntelUsageFromRC6(t *testing.T) {
+	root := filepath.Join("testdata", "drm", "intel")
+	now := time.Now()
+
+	c := &Collector{}
+	first := c.readDRMGPUs(root, now)
+	if len(first) != 1 || first[0].Usage != -1 {
+		t.Fatalf("first sample = %+v, want unknown usage", first)
+	}
+	if first[0].FreqMHz != 1300 || first[0].MaxFreqMHz != 1500 || first[0].Name != "Intel Graphics" {
+		t.Errorf("intel GPU = %+v", first[0])
+	}
+
+	// 400ms of RC6 idle over a 1s interval means 60% busy.
+	c.prevGPUIdle["card0"] = gpuIdleSample{idleMs: 250000, at: now.Add(-time.Second)}
+	if got := c.readDRMGPUs(root, now)[0].Usage; got != 60 {
+		t.Errorf("usage = %v, want 60", got)
+	}
+}
+
+func TestIdleToBusy(t *testing.T) {
+	start := time.Unix(1000, 0)
+	tests := []struct {
+		name string
+		prev gpuIdleSample
+		cur  gpuIdleSample
+		want float64
+	}{
+		{"fully idle", gpuIdleSample{0, start}, gpuIdleSample{1000, start.Add(time.Second)}, 0},
+		{"busy", gpuIdleSample{0, start}, gpuIdleSample{250, start.Add(time.Second)}, 75},
+		{"clock skew clamps", gpuIdleSample{0, start}, gpuIdleSample{1200, start.Add(time.Second)}, 0},
+		{"counter reset", gpuIdleSample{5000, start}, gpuIdleSample{10, start.Add(time.Second)}, -1},
+		{"no elapsed time", gpuIdleSample{0, start}, gpuIdleSample{0, start}, -1},
+	}
+	for _, tt := range tests {
+		if got := idleToBusy(tt.prev, tt.cur); got != tt.want {
+			t.Errorf("%s: idleToBusy = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseDPMClock(t *testing.T) {
+	cur, maxMHz := parseDPMClock("0: 500Mhz \n1: 1850Mhz *\n2: 2250Mhz \n")
+	if cur != 1850 || maxMHz != 2250 {
+		t.Errorf("parseDPMClock = %d/%d, want 1850/2250", cur, maxMHz)
+	}
+	if cur, maxMHz := parseDPMClock(""); cur != 0 || maxMHz != 0 {
+		t.Errorf("empty = %d/%d", cur, maxMHz)
+	}
+}
+
+func TestGPUCardVisibility(t *testing.T) {
+	macOnly := []GPUStatus{{Name: "Apple M2", Usage: -1, CoreCount: 10}}
+	if hasGPUData(macOnly) {
+		t.Error("model-only GPU should not get a card")
+	}
+	amd := []GPUStatus{{Name: "AMD Radeon", Usage: 37, MemoryUsed: 2048, MemoryTotal: 8192, FreqMHz: 1850, MaxFreqMHz: 2250, Temp: 52}}
+	if !hasGPUData(amd) {
+		t.Fatal("GPU with live metrics should get a card")
+	}
+	text := stripANSI(strings.Join(renderGPUCard(amd).lines, "\n"))
+	for _, want := range []string{"AMD Radeon", "37.0%", "25.0%", "1850/2250 MHz", "52.0°C"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("card missing %q:\n%s", want, text)
+		}
+	}
+}
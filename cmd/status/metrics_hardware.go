@@ -3,25 +3,28 @@ package main
 import (
 	"context"
 	"fmt"
-	"runtime"
 	"strings"
 	"time"
 )
 
-func collectHardware(totalRAM uint64, disks []DiskStatus) HardwareInfo {
-	if runtime.GOOS != "darwin" {
+// hardwareRefreshInterval is long because hardware info rarely changes and is
+// expensive to collect.
+const hardwareRefreshInterval = 10 * time.Minute
+
+func collectHardware(ctx context.Context, totalRAM uint64, disks []DiskStatus) HardwareInfo {
+	if p := platformFrom(ctx); p.goos != "darwin" {
 		return HardwareInfo{
 			Model:       "Unknown",
-			CPUModel:    runtime.GOARCH,
+			CPUModel:    p.goarch,
 			TotalRAM:    humanBytes(totalRAM),
 			DiskSize:    "Unknown",
-			OSVersion:   runtime.GOOS,
+			OSVersion:   p.goos,
 			RefreshRate: "",
 		}
 	}
 
 	// Model and CPU from system_profiler.
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	var model, cpuModel, osVersion, refreshRate string
@@ -52,7 +55,7 @@ func collectHardware(totalRAM uint64, disks []DiskStatus) HardwareInfo {
 		}
 	}
 
-	ctx2, cancel2 := context.WithTimeout(context.Background(), 1*time.Second)
+	ctx2, cancel2 := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel2()
 	out2, err := runCmd(ctx2, "sw_vers", "-productVersion")
 	if err == nil {
@@ -60,7 +63,7 @@ func collectHardware(totalRAM uint64, disks []DiskStatus) HardwareInfo {
 	}
 
 	// Get refresh rate from display info (use mini detail to keep it fast).
-	ctx3, cancel3 := context.WithTimeout(context.Background(), 2*time.Second)
+	ctx3, cancel3 := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel3()
 	out3, err := runCmd(ctx3, "system_profiler", "-detailLevel", "mini", "SPDisplaysDataType")
 	if err == nil {
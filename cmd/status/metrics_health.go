@@ -32,12 +32,19 @@ const (
 	thermalNormalThreshold = 60.0
 	thermalHighThreshold   = 85.0
 
-	// Disk IO (MB/s).
+	// Drive health.
+	driveWarnPenalty = 5.0
+	driveCritPenalty = 20.0
+
+	// Suspected memory leaks.
+	leakPenalty = 5.0
+
+	// Disk IO (MiB/s).
 	ioNormalThreshold = 50.0
 	ioHighThreshold   = 150.0
 )
 
-func calculateHealthScore(cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, diskIO DiskIOStatus, thermal ThermalStatus) (int, string) {
+func calculateHealthScore(cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, diskIO DiskIOStatus, thermal ThermalStatus, drives []DriveHealth, leaks []LeakSuspect) (int, string) {
 	score := 100.0
 	issues := []string{}
 
@@ -123,6 +130,27 @@ func calculateHealthScore(cpu CPUStatus, mem MemoryStatus, disks []DiskStatus, d
 	}
 	score -= ioPenalty
 
+	// Drive health penalty, worst drive only.
+	driveLevel := "ok"
+	for _, d := range drives {
+		if level := d.Level(); level == "critical" || (level == "warn" && driveLevel == "ok") {
+			driveLevel = level
+		}
+	}
+	switch driveLevel {
+	case "warn":
+		score -= driveWarnPenalty
+		issues = append(issues, "Drive Wear")
+	case "critical":
+		score -= driveCritPenalty
+		issues = append(issues, "Drive Failing")
+	}
+
+	if len(leaks) > 0 {
+		score -= leakPenalty
+		issues = append(issues, "Memory Leak")
+	}
+
 	// Clamp score.
 	if score < 0 {
 		score = 0
@@ -12,6 +12,8 @@ func TestCalculateHealthScorePerfect(t *testing.T) {
 		[]DiskStatus{{UsedPercent: 30}},
 		DiskIOStatus{ReadRate: 5, WriteRate: 5},
 		ThermalStatus{CPUTemp: 40},
+		nil,
+		nil,
 	)
 
 	if score != 100 {
@@ -29,6 +31,8 @@ func TestCalculateHealthScoreDetectsIssues(t *testing.T) {
 		[]DiskStatus{{UsedPercent: 95}},
 		DiskIOStatus{ReadRate: 120, WriteRate: 80},
 		ThermalStatus{CPUTemp: 90},
+		nil,
+		[]LeakSuspect{{Name: "leaky"}},
 	)
 
 	if score >= 40 {
@@ -43,6 +47,9 @@ func TestCalculateHealthScoreDetectsIssues(t *testing.T) {
 	if !strings.Contains(msg, "Disk Almost Full") {
 		t.Fatalf("message should mention disk issue: %q", msg)
 	}
+	if !strings.Contains(msg, "Memory Leak") {
+		t.Fatalf("message should mention suspected leaks: %q", msg)
+	}
 }
 
 func TestFormatUptime(t *testing.T) {
@@ -160,7 +167,7 @@ func TestCalculateHealthScoreEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			score, _ := calculateHealthScore(tt.cpu, tt.mem, tt.disks, tt.diskIO, tt.thermal)
+			score, _ := calculateHealthScore(tt.cpu, tt.mem, tt.disks, tt.diskIO, tt.thermal, nil, nil)
 			if score < tt.wantMin || score > tt.wantMax {
 				t.Errorf("calculateHealthScore() = %d, want range [%d, %d]", score, tt.wantMin, tt.wantMax)
 			}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// Leak detection thresholds.
+const (
+	leakWatchCount   = 20 // Largest processes whose RSS is tracked
+	leakWindow       = 5 * time.Minute
+	leakMinSpan      = 2 * time.Minute // Growth must be sustained this long
+	leakMinSamples   = 8
+	leakMinGrowth    = 50 << 20 // Bytes gained over the window
+	leakMinGrowthPct = 10.0     // Percent of the RSS at the start of the window
+	leakDipTolerance = 0.01     // Allocator noise: RSS may fall this far below its peak
+)
+
+type rssSample struct {
+	at  time.Time
+	rss uint64
+}
+
+// rssHistory is one process's resident set size over the leak window,
+// oldest first.
+type rssHistory struct {
+	name    string
+	samples []rssSample
+}
+
+// trackRSS records the largest processes' RSS and returns those that have
+// grown steadily across the window, fastest first. Processes that exit or
+// drop out of the largest leakWatchCount lose their history.
+func (c *Collector) trackRSS(procs []ProcessInfo, now time.Time) []LeakSuspect {
+	largest := make([]ProcessInfo, len(procs))
+	copy(largest, procs)
+	sort.SliceStable(largest, func(i, j int) bool { return largest[i].RSS > largest[j].RSS })
+	largest = largest[:min(leakWatchCount, len(largest))]
+
+	history := make(map[int32]*rssHistory, len(largest))
+	var leaks []LeakSuspect
+	for _, p := range largest {
+		h, ok := c.rssHistory[p.PID]
+		if !ok || h.name != p.Name {
+			// A new process, or a reused PID.
+			h = &rssHistory{name: p.Name}
+		}
+		h.samples = append(h.samples, rssSample{at: now, rss: p.RSS})
+		cutoff := now.Add(-leakWindow)
+		drop := sort.Search(len(h.samples), func(i int) bool { return !h.samples[i].at.Before(cutoff) })
+		h.samples = h.samples[drop:]
+		history[p.PID] = h
+
+		if leak, ok := h.suspect(); ok {
+			leak.PID = p.PID
+			leaks = append(leaks, leak)
+		}
+	}
+	c.rssHistory = history
+
+	sort.SliceStable(leaks, func(i, j int) bool { return leaks[i].Rate > leaks[j].Rate })
+	return leaks
+}
+
+// suspect reports whether the history shows sustained growth: enough
+// samples over leakMinSpan, a large enough gain, and no dip below the
+// running peak beyond allocator noise.
+func (h *rssHistory) suspect() (LeakSuspect, bool) {
+	if len(h.samples) < leakMinSamples {
+		return LeakSuspect{}, false
+	}
+	first, last := h.samples[0], h.samples[len(h.samples)-1]
+	span := last.at.Sub(first.at)
+	if span < leakMinSpan || last.rss <= first.rss {
+		return LeakSuspect{}, false
+	}
+	growth := last.rss - first.rss
+	if growth < leakMinGrowth || float64(growth) < float64(first.rss)*leakMinGrowthPct/100 {
+		return LeakSuspect{}, false
+	}
+	peak := first.rss
+	for _, s := range h.samples[1:] {
+		if float64(s.rss) < float64(peak)*(1-leakDipTolerance) {
+			return LeakSuspect{}, false
+		}
+		peak = max(peak, s.rss)
+	}
+	return LeakSuspect{
+		Name:   h.name,
+		RSS:    last.rss,
+		Growth: growth,
+		Rate:   counterRate(first.rss, last.rss, span.Seconds()),
+		Since:  first.at,
+	}, true
+}
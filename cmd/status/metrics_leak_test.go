@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrackRSS(t *testing.T) {
+	c := &Collector{}
+	start := time.Now()
+	var leaks []LeakSuspect
+	for i := range 31 {
+		now := start.Add(time.Duration(i) * 10 * time.Second)
+		// Wobbles within allocator noise of its peak.
+		jitter := uint64(0)
+		if i%2 == 1 {
+			jitter = 4 << 20
+		}
+		leaks = c.trackRSS([]ProcessInfo{
+			{PID: 1, Name: "leaky", RSS: 1<<30 + uint64(i)*(8<<20) - jitter},
+			{PID: 2, Name: "steady", RSS: 2 << 30},
+			{PID: 3, Name: "sawtooth", RSS: 1<<30 + uint64(i%5)*(100<<20)},
+			{PID: 4, Name: "tiny", RSS: 10<<20 + uint64(i)*(1<<20)},
+		}, now)
+	}
+	if len(leaks) != 1 || leaks[0].PID != 1 || leaks[0].Name != "leaky" {
+		t.Fatalf("leaks = %+v", leaks)
+	}
+	// The window slides: 5 minutes at 10s intervals, 8 MB per step.
+	l := leaks[0]
+	if got := start.Add(300 * time.Second).Sub(l.Since); got != leakWindow {
+		t.Errorf("window = %v", got)
+	}
+	if l.Growth != 30*(8<<20) {
+		t.Errorf("growth = %d", l.Growth)
+	}
+	if rate := l.Rate * 60; rate < 47.9 || rate > 48.1 {
+		t.Errorf("rate = %.2f MB/min", rate)
+	}
+
+	// A reused PID starts a fresh history.
+	leaks = c.trackRSS([]ProcessInfo{{PID: 1, Name: "other", RSS: 2 << 30}}, start.Add(310*time.Second))
+	if len(leaks) != 0 || len(c.rssHistory[1].samples) != 1 {
+		t.Errorf("reused pid kept history: %+v", leaks)
+	}
+}
+
+func TestTrackRSSWatchesLargest(t *testing.T) {
+	c := &Collector{}
+	procs := make([]ProcessInfo, leakWatchCount+5)
+	for i := range procs {
+		procs[i] = ProcessInfo{PID: int32(i + 1), Name: "p", RSS: uint64(i+1) << 20}
+	}
+	c.trackRSS(procs, time.Now())
+	if len(c.rssHistory) != leakWatchCount {
+		t.Errorf("tracked %d processes, want %d", len(c.rssHistory), leakWatchCount)
+	}
+	if _, ok := c.rssHistory[1]; ok {
+		t.Error("smallest process tracked")
+	}
+}
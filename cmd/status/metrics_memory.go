@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
-	"runtime"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -10,22 +12,60 @@ import (
 	"github.com/shirou/gopsutil/v4/mem"
 )
 
-func collectMemory() (MemoryStatus, error) {
-	vm, err := mem.VirtualMemory()
+// topMemoryCount is how many of the largest processes the memory card lists.
+const topMemoryCount = 3
+
+// pagingCounters are cumulative swap traffic and page faults.
+type pagingCounters struct {
+	swapIn      uint64 // Bytes
+	swapOut     uint64 // Bytes
+	faults      uint64
+	majorFaults uint64
+}
+
+func (c *Collector) collectMemory(ctx context.Context, now time.Time) (MemoryStatus, error) {
+	p := platformFrom(ctx)
+	vm, err := p.virtualMemory(ctx)
 	if err != nil {
 		return MemoryStatus{}, err
 	}
 
-	swap, _ := mem.SwapMemory()
-	pressure := getMemoryPressure()
+	swap, err := p.swapMemory(ctx)
+	if err != nil {
+		swap = &mem.SwapMemoryStat{}
+	}
+	pressure := getMemoryPressure(ctx)
 
-	// On macOS, vm.Cached is 0, so we calculate from file-backed pages.
-	cached := vm.Cached
-	if runtime.GOOS == "darwin" && cached == 0 {
-		cached = getFileBackedMemory()
+	var (
+		cached    = vm.Cached
+		stall     float64
+		breakdown []MemorySegment
+		counters  pagingCounters
+		paging    bool
+	)
+	switch p.goos {
+	case "darwin":
+		// vm.Cached is 0 on macOS, so cache is the file-backed pages.
+		if stat, ok := readVMStat(ctx); ok {
+			if cached == 0 {
+				cached = stat.bytes("File-backed pages")
+			}
+			breakdown = stat.breakdown()
+			counters, paging = stat.counters(), true
+		}
+	case "linux":
+		if info := readMeminfo(p.procRoot); len(info) > 0 {
+			cached = reclaimableCache(info)
+			breakdown = meminfoBreakdown(info)
+		}
+		if psi, ok := readPSI(p.procRoot, "memory"); ok {
+			pressure = psiMemoryLevel(psi)
+			stall = psi.Some10
+		}
+		counters, paging = readProcVMStat(p.procRoot)
 	}
 
-	return MemoryStatus{
+	status := MemoryStatus{
 		Used:        vm.Used,
 		Total:       vm.Total,
 		UsedPercent: vm.UsedPercent,
@@ -33,53 +73,161 @@ func collectMemory() (MemoryStatus, error) {
 		SwapTotal:   swap.Total,
 		Cached:      cached,
 		Pressure:    pressure,
-	}, nil
+		Stall:       stall,
+		Breakdown:   breakdown,
+	}
+	if paging {
+		c.pagingRates(&status, counters, now)
+	}
+	return status, nil
 }
 
-func getFileBackedMemory() uint64 {
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+// pagingRates fills the swap and fault rates since the previous sample.
+func (c *Collector) pagingRates(status *MemoryStatus, cur pagingCounters, now time.Time) {
+	prev, elapsed := c.prevPaging, now.Sub(c.lastPagingAt).Seconds()
+	c.prevPaging, c.lastPagingAt = cur, now
+	if prev == (pagingCounters{}) || elapsed <= 0 {
+		return
+	}
+	perSecond := func(prev, cur uint64) float64 {
+		if cur < prev {
+			return 0
+		}
+		return float64(cur-prev) / elapsed
+	}
+	status.SwapInRate = counterRate(prev.swapIn, cur.swapIn, elapsed)
+	status.SwapOutRate = counterRate(prev.swapOut, cur.swapOut, elapsed)
+	status.PageFaultRate = perSecond(prev.faults, cur.faults)
+	status.MajorFaultRate = perSecond(prev.majorFaults, cur.majorFaults)
+}
+
+// vmStat is one vm_stat report: page counts and counters by name.
+type vmStat struct {
+	pageSize uint64
+	values   map[string]uint64
+}
+
+func readVMStat(ctx context.Context) (vmStat, bool) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 	out, err := runCmd(ctx, "vm_stat")
 	if err != nil {
-		return 0
+		return vmStat{}, false
 	}
+	stat := parseVMStat(out)
+	return stat, len(stat.values) > 0
+}
 
-	// Parse page size from first line: "Mach Virtual Memory Statistics: (page size of 16384 bytes)"
-	var pageSize uint64 = 4096 // Default
-	firstLine := true
+// parseVMStat reads lines such as "File-backed pages:  388975." after the
+// "Mach Virtual Memory Statistics: (page size of 16384 bytes)" header.
+func parseVMStat(out string) vmStat {
+	stat := vmStat{pageSize: 4096, values: make(map[string]uint64)}
 	for line := range strings.Lines(out) {
-		if firstLine {
-			firstLine = false
-			if strings.Contains(line, "page size of") {
-				if _, after, found := strings.Cut(line, "page size of "); found {
-					if before, _, found := strings.Cut(after, " bytes"); found {
-						if size, err := strconv.ParseUint(strings.TrimSpace(before), 10, 64); err == nil {
-							pageSize = size
-						}
-					}
+		if _, after, found := strings.Cut(line, "page size of "); found {
+			if before, _, found := strings.Cut(after, " bytes"); found {
+				if size, err := strconv.ParseUint(strings.TrimSpace(before), 10, 64); err == nil {
+					stat.pageSize = size
 				}
 			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(value), "."), 10, 64)
+		if err != nil {
+			continue
 		}
+		stat.values[strings.Trim(key, `"`)] = n
+	}
+	return stat
+}
 
-		// Parse "File-backed pages: 388975."
-		if strings.Contains(line, "File-backed pages:") {
-			if _, after, found := strings.Cut(line, ":"); found {
-				numStr := strings.TrimSpace(after)
-				numStr = strings.TrimSuffix(numStr, ".")
-				if pages, err := strconv.ParseUint(numStr, 10, 64); err == nil {
-					return pages * pageSize
-				}
-			}
+// bytes converts a page count to bytes.
+func (s vmStat) bytes(key string) uint64 {
+	return s.values[key] * s.pageSize
+}
+
+// breakdown is memory as Activity Monitor splits it.
+func (s vmStat) breakdown() []MemorySegment {
+	return []MemorySegment{
+		{Name: "wired", Bytes: s.bytes("Pages wired down")},
+		{Name: "active", Bytes: s.bytes("Pages active")},
+		{Name: "inactive", Bytes: s.bytes("Pages inactive")},
+		{Name: "compressed", Bytes: s.bytes("Pages occupied by compressor")},
+	}
+}
+
+// counters treats pageins, which read from disk, as major faults.
+func (s vmStat) counters() pagingCounters {
+	return pagingCounters{
+		swapIn:      s.bytes("Swapins"),
+		swapOut:     s.bytes("Swapouts"),
+		faults:      s.values["Translation faults"],
+		majorFaults: s.values["Pageins"],
+	}
+}
+
+// meminfoBreakdown splits used memory into anonymous, page cache, kernel slab
+// and shared memory (tmpfs, shm). Shmem is counted in Cached, so it is taken
+// out of the file pages.
+func meminfoBreakdown(info map[string]uint64) []MemorySegment {
+	file := info["Cached"] + info["Buffers"]
+	file -= min(info["Shmem"], file)
+	return []MemorySegment{
+		{Name: "anon", Bytes: info["AnonPages"]},
+		{Name: "file", Bytes: file},
+		{Name: "slab", Bytes: info["Slab"]},
+		{Name: "shmem", Bytes: info["Shmem"]},
+	}
+}
+
+// readProcVMStat reads swap traffic and page faults from /proc/vmstat, where
+// swap is counted in pages.
+func readProcVMStat(root string) (pagingCounters, bool) {
+	values := make(map[string]uint64)
+	for line := range strings.Lines(readSysString(filepath.Join(root, "vmstat"))) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+			values[key] = n
+		}
+	}
+	if len(values) == 0 {
+		return pagingCounters{}, false
+	}
+	pageSize := uint64(os.Getpagesize())
+	return pagingCounters{
+		swapIn:      values["pswpin"] * pageSize,
+		swapOut:     values["pswpout"] * pageSize,
+		faults:      values["pgfault"],
+		majorFaults: values["pgmajfault"],
+	}, true
+}
+
+// topMemoryConsumers returns the n processes with the largest resident set.
+func topMemoryConsumers(procs []ProcessInfo, n int) []MemoryConsumer {
+	sorted := make([]ProcessInfo, len(procs))
+	copy(sorted, procs)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].RSS > sorted[j].RSS })
+	var top []MemoryConsumer
+	for _, p := range sorted[:min(n, len(sorted))] {
+		if p.RSS == 0 {
+			break
 		}
+		top = append(top, MemoryConsumer{PID: p.PID, Name: p.Name, RSS: p.RSS})
 	}
-	return 0
+	return top
 }
 
-func getMemoryPressure() string {
-	if runtime.GOOS != "darwin" {
+func getMemoryPressure(ctx context.Context) string {
+	if platformFrom(ctx).goos != "darwin" {
 		return ""
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 	out, err := runCmd(ctx, "memory_pressure")
 	if err != nil {
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseVMStat(t *testing.T) {
+	stat := parseVMStat(`Mach Virtual Memory Statistics: (page size of 16384 bytes)
+Pages free:                               12345.
+Pages wired down:                           100.
+"Translation faults":                   9876543.
+Swapins:                                     10.
+Swapouts:                                    20.
+Pageins:                                    300.
+`)
+	if stat.pageSize != 16384 {
+		t.Errorf("page size = %d", stat.pageSize)
+	}
+	if got := stat.bytes("Pages wired down"); got != 100*16384 {
+		t.Errorf("wired = %d", got)
+	}
+	want := pagingCounters{swapIn: 10 * 16384, swapOut: 20 * 16384, faults: 9876543, majorFaults: 300}
+	if got := stat.counters(); got != want {
+		t.Errorf("counters = %+v, want %+v", got, want)
+	}
+}
+
+func TestMeminfoBreakdown(t *testing.T) {
+	got := meminfoBreakdown(map[string]uint64{
+		"AnonPages": 4 << 30, "Cached": 3 << 30, "Buffers": 1 << 30, "Slab": 512 << 20, "Shmem": 1 << 30,
+	})
+	want := []MemorySegment{{"anon", 4 << 30}, {"file", 3 << 30}, {"slab", 512 << 20}, {"shmem", 1 << 30}}
+	if !slices.Equal(got, want) {
+		t.Errorf("breakdown = %+v, want %+v", got, want)
+	}
+	// Shmem larger than the page cache must not wrap.
+	if got := meminfoBreakdown(map[string]uint64{"Cached": 1, "Shmem": 2}); got[1].Bytes != 0 {
+		t.Errorf("file = %d", got[1].Bytes)
+	}
+}
+
+func TestReadProcVMStat(t *testing.T) {
+	root := t.TempDir()
+	if _, ok := readProcVMStat(root); ok {
+		t.Error("missing vmstat reported counters")
+	}
+	writeSysfs(t, root, map[string]string{"vmstat": "nr_free_pages 1000\npswpin 3\npswpout 5\npgfault 700\npgmajfault 9"})
+	got, ok := readProcVMStat(root)
+	page := uint64(os.Getpagesize())
+	want := pagingCounters{swapIn: 3 * page, swapOut: 5 * page, faults: 700, majorFaults: 9}
+	if !ok || got != want {
+		t.Errorf("counters = %+v, %v; want %+v", got, ok, want)
+	}
+}
+
+func TestPagingRates(t *testing.T) {
+	c := &Collector{}
+	now := time.Now()
+	var first MemoryStatus
+	c.pagingRates(&first, pagingCounters{swapIn: 1 << 20, faults: 100}, now)
+	if first.SwapInRate != 0 || first.PageFaultRate != 0 {
+		t.Errorf("first sample has rates: %+v", first)
+	}
+	var second MemoryStatus
+	c.pagingRates(&second, pagingCounters{swapIn: 5 << 20, swapOut: 2 << 20, faults: 300, majorFaults: 4}, now.Add(2*time.Second))
+	if second.SwapInRate != 2 || second.SwapOutRate != 1 || second.PageFaultRate != 100 || second.MajorFaultRate != 2 {
+		t.Errorf("rates = %+v", second)
+	}
+}
+
+func TestTopMemoryConsumers(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 1, Name: "small", RSS: 10},
+		{PID: 2, Name: "big", RSS: 300},
+		{PID: 3, Name: "kernel"},
+		{PID: 4, Name: "mid", RSS: 200},
+	}
+	want := []MemoryConsumer{{2, "big", 300}, {4, "mid", 200}}
+	if got := topMemoryConsumers(procs, 2); !slices.Equal(got, want) {
+		t.Errorf("top = %+v, want %+v", got, want)
+	}
+	if got := topMemoryConsumers(procs, 10); len(got) != 3 {
+		t.Errorf("processes without RSS listed: %+v", got)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"net/url"
 	"os"
-	"runtime"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,14 +12,14 @@ import (
 	"github.com/shirou/gopsutil/v4/net"
 )
 
-func (c *Collector) collectNetwork(now time.Time) ([]NetworkStatus, error) {
-	stats, err := net.IOCounters(true)
+func (c *Collector) collectNetwork(ctx context.Context, now time.Time) ([]NetworkStatus, error) {
+	stats, err := platformFrom(ctx).netCounters(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Map interface IPs.
-	ifAddrs := getInterfaceIPs()
+	ifAddrs := getInterfaceIPs(ctx)
 
 	if c.lastNetAt.IsZero() {
 		c.lastNetAt = now
@@ -44,33 +43,36 @@ func (c *Collector) collectNetwork(now time.Time) ([]NetworkStatus, error) {
 		if !ok {
 			continue
 		}
-		rx := float64(cur.BytesRecv-prev.BytesRecv) / 1024.0 / 1024.0 / elapsed
-		tx := float64(cur.BytesSent-prev.BytesSent) / 1024.0 / 1024.0 / elapsed
-		if rx < 0 {
-			rx = 0
-		}
-		if tx < 0 {
-			tx = 0
+		status := interfaceRates(prev, cur, elapsed)
+		status.IP = ifAddrs[cur.Name]
+
+		buf := c.ifaceHistory[cur.Name]
+		if buf == nil {
+			buf = &netHistoryBuf{rx: NewRingBuffer(NetworkHistorySize), tx: NewRingBuffer(NetworkHistorySize)}
+			c.ifaceHistory[cur.Name] = buf
 		}
-		result = append(result, NetworkStatus{
-			Name:      cur.Name,
-			RxRateMBs: rx,
-			TxRateMBs: tx,
-			IP:        ifAddrs[cur.Name],
-		})
+		buf.rx.Add(status.RxRateMBs)
+		buf.tx.Add(status.TxRateMBs)
+		status.History = NetworkHistory{RxHistory: buf.rx.Slice(), TxHistory: buf.tx.Slice()}
+		result = append(result, status)
 	}
 
 	c.lastNetAt = now
+	seen := make(map[string]bool, len(stats))
 	for _, s := range stats {
 		c.prevNet[s.Name] = s
+		seen[s.Name] = true
+	}
+	// Forget interfaces that went away, e.g. an unplugged adapter or VPN.
+	for name := range c.ifaceHistory {
+		if !seen[name] {
+			delete(c.ifaceHistory, name)
+		}
 	}
 
 	sort.Slice(result, func(i, j int) bool {
 		return result[i].RxRateMBs+result[i].TxRateMBs > result[j].RxRateMBs+result[j].TxRateMBs
 	})
-	if len(result) > 3 {
-		result = result[:3]
-	}
 
 	var totalRx, totalTx float64
 	for _, r := range result {
@@ -85,9 +87,33 @@ func (c *Collector) collectNetwork(now time.Time) ([]NetworkStatus, error) {
 	return result, nil
 }
 
-func getInterfaceIPs() map[string]string {
+// netHistoryBuf is one interface's rx/tx history.
+type netHistoryBuf struct {
+	rx, tx *RingBuffer
+}
+
+// interfaceRates converts two counter samples into per-second rates.
+func interfaceRates(prev, cur net.IOCountersStat, elapsed float64) NetworkStatus {
+	perSecond := func(prev, cur uint64) float64 {
+		if cur < prev {
+			return 0
+		}
+		return float64(cur-prev) / elapsed
+	}
+	return NetworkStatus{
+		Name:      cur.Name,
+		RxRateMBs: counterRate(prev.BytesRecv, cur.BytesRecv, elapsed),
+		TxRateMBs: counterRate(prev.BytesSent, cur.BytesSent, elapsed),
+		RxPackets: perSecond(prev.PacketsRecv, cur.PacketsRecv),
+		TxPackets: perSecond(prev.PacketsSent, cur.PacketsSent),
+		Errors:    cur.Errin + cur.Errout,
+		Drops:     cur.Dropin + cur.Dropout,
+	}
+}
+
+func getInterfaceIPs(ctx context.Context) map[string]string {
 	result := make(map[string]string)
-	ifaces, err := net.Interfaces()
+	ifaces, err := platformFrom(ctx).netInterfaces(ctx)
 	if err != nil {
 		return result
 	}
@@ -115,14 +141,14 @@ func isNoiseInterface(name string) bool {
 	return false
 }
 
-func collectProxy() ProxyStatus {
+func collectProxy(ctx context.Context) ProxyStatus {
 	if proxy := collectProxyFromEnv(os.Getenv); proxy.Enabled {
 		return proxy
 	}
 
 	// macOS: check system proxy via scutil.
-	if runtime.GOOS == "darwin" {
-		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	if platformFrom(ctx).goos == "darwin" {
+		ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 		defer cancel()
 		out, err := runCmd(ctx, "scutil", "--proxy")
 		if err == nil {
@@ -131,7 +157,7 @@ func collectProxy() ProxyStatus {
 			}
 		}
 
-		if proxy := collectProxyFromTunInterfaces(); proxy.Enabled {
+		if proxy := collectProxyFromTunInterfaces(ctx); proxy.Enabled {
 			return proxy
 		}
 	}
@@ -213,8 +239,8 @@ func collectProxyFromScutilOutput(out string) ProxyStatus {
 	return ProxyStatus{Enabled: false}
 }
 
-func collectProxyFromTunInterfaces() ProxyStatus {
-	stats, err := net.IOCounters(true)
+func collectProxyFromTunInterfaces(ctx context.Context) ProxyStatus {
+	stats, err := platformFrom(ctx).netCounters(ctx)
 	if err != nil {
 		return ProxyStatus{Enabled: false}
 	}
@@ -1,6 +1,11 @@
 package main
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/net"
+)
 
 func TestCollectProxyFromEnvSupportsAllProxy(t *testing.T) {
 	env := map[string]string{
@@ -58,3 +63,63 @@ func TestCollectProxyFromScutilOutputHTTPHostPort(t *testing.T) {
 		t.Fatalf("unexpected host: %s", got.Host)
 	}
 }
+
+func TestInterfaceRates(t *testing.T) {
+	prev := net.IOCountersStat{Name: "eth0", BytesRecv: 0, BytesSent: 1 << 20, PacketsRecv: 1000, PacketsSent: 500, Errin: 1}
+	cur := net.IOCountersStat{Name: "eth0", BytesRecv: 4 << 20, BytesSent: 1 << 20, PacketsRecv: 3000, PacketsSent: 100, Errin: 2, Errout: 1, Dropin: 7}
+
+	got := interfaceRates(prev, cur, 2)
+	want := NetworkStatus{Name: "eth0", RxRateMBs: 2, RxPackets: 1000, Errors: 3, Drops: 7}
+	if got.Name != want.Name || got.RxRateMBs != want.RxRateMBs || got.TxRateMBs != 0 ||
+		got.RxPackets != want.RxPackets || got.TxPackets != 0 || got.Errors != want.Errors || got.Drops != want.Drops {
+		t.Errorf("interfaceRates = %+v, want %+v (sent packets reset to 0)", got, want)
+	}
+}
+
+func TestNextInterfaceCycles(t *testing.T) {
+	stats := []NetworkStatus{{Name: "wlan0"}, {Name: "eth0"}, {Name: "tun0"}}
+	var seen []string
+	cur := ""
+	for range 4 {
+		cur = nextInterface(stats, cur)
+		seen = append(seen, cur)
+	}
+	if got := strings.Join(seen, ","); got != "eth0,tun0,wlan0," {
+		t.Errorf("cycle = %q", got)
+	}
+	// A vanished interface moves on to the next name.
+	if got := nextInterface(stats, "eth1"); got != "tun0" {
+		t.Errorf("after eth1 = %q", got)
+	}
+}
+
+func TestNetworkCardFocusedInterface(t *testing.T) {
+	snap := MetricsSnapshot{
+		Network: []NetworkStatus{
+			{Name: "eth0", RxRateMBs: 2, IP: "10.0.0.5", RxPackets: 1500, TxPackets: 20, Drops: 4,
+				History: NetworkHistory{RxHistory: []float64{1, 2}, TxHistory: []float64{0, 0}}},
+			{Name: "wlan0", TxRateMBs: 1},
+		},
+		NetworkHistory: NetworkHistory{RxHistory: []float64{3}, TxHistory: []float64{1}},
+	}
+
+	all := stripANSI(strings.Join(renderNetworkCard(snap.Network, snap.NetworkHistory, ProxyStatus{}, nil, 60).lines, "\n"))
+	if strings.Contains(all, "pkt/s") {
+		t.Errorf("aggregate card shows per-interface counters:\n%s", all)
+	}
+
+	focused := focusNetwork(snap, "eth0")
+	if len(focused.NetworkHistory.RxHistory) != 2 {
+		t.Errorf("focused history = %+v", focused.NetworkHistory)
+	}
+	card := stripANSI(strings.Join(renderNetworkCard(focused.Network, focused.NetworkHistory, ProxyStatus{}, nil, 60).lines, "\n"))
+	for _, want := range []string{"2.0 MiB/s", "eth0   1.5k/20 pkt/s", "0 errs · 4 drops", "10.0.0.5"} {
+		if !strings.Contains(card, want) {
+			t.Errorf("focused card missing %q:\n%s", want, card)
+		}
+	}
+
+	if got := focusNetwork(snap, "gone0"); len(got.Network) != 2 {
+		t.Error("unknown interface should show all")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Memory PSI thresholds (avg10 %) mapped onto the macOS pressure levels.
+const (
+	psiMemWarnSome = 10.0
+	psiMemCritSome = 40.0
+	psiMemCritFull = 10.0
+)
+
+// psiStat is one /proc/pressure file. Some is the share of wall time at least
+// one task stalled on the resource, full the share all non-idle tasks did.
+type psiStat struct {
+	Some10 float64
+	Full10 float64
+}
+
+// readPSI reads /proc/pressure/<resource>; ok is false without PSI support.
+func readPSI(root, resource string) (psiStat, bool) {
+	raw := readSysString(filepath.Join(root, "pressure", resource))
+	if raw == "" {
+		return psiStat{}, false
+	}
+	return parsePSI(raw)
+}
+
+// parsePSI parses lines like "some avg10=1.50 avg60=0.80 avg300=0.20 total=123".
+func parsePSI(raw string) (psiStat, bool) {
+	var stat psiStat
+	found := false
+	for line := range strings.Lines(raw) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		value, ok := strings.CutPrefix(fields[1], "avg10=")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "some":
+			stat.Some10 = v
+			found = true
+		case "full":
+			stat.Full10 = v
+		}
+	}
+	return stat, found
+}
+
+// psiMemoryLevel converts memory PSI into normal/warn/critical.
+func psiMemoryLevel(stat psiStat) string {
+	switch {
+	case stat.Full10 >= psiMemCritFull || stat.Some10 >= psiMemCritSome:
+		return "critical"
+	case stat.Some10 >= psiMemWarnSome:
+		return "warn"
+	default:
+		return "normal"
+	}
+}
+
+// readMeminfo parses /proc/meminfo into bytes keyed by field name.
+func readMeminfo(root string) map[string]uint64 {
+	info := make(map[string]uint64)
+	for line := range strings.Lines(readSysString(filepath.Join(root, "meminfo"))) {
+		key, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			v *= 1024
+		}
+		info[key] = v
+	}
+	return info
+}
+
+// reclaimableCache is page cache plus reclaimable slab, minus shared memory
+// (tmpfs, shm) that lives in the page cache but cannot be dropped.
+func reclaimableCache(info map[string]uint64) uint64 {
+	cache := info["Cached"] + info["Buffers"] + info["SReclaimable"]
+	if shmem := info["Shmem"]; shmem < cache {
+		return cache - shmem
+	}
+	return 0
+}
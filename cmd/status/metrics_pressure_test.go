@@ -0,0 +1,94 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testProcRoot = filepath.Join("testdata", "proc")
+
+func TestReadPSI(t *testing.T) {
+	tests := []struct {
+		resource string
+		want     psiStat
+	}{
+		{"memory", psiStat{Some10: 12.5, Full10: 3.25}},
+		{"cpu", psiStat{Some10: 7.8}},
+		{"io", psiStat{Some10: 2.1, Full10: 1.05}},
+	}
+	for _, tt := range tests {
+		got, ok := readPSI(testProcRoot, tt.resource)
+		if !ok || got != tt.want {
+			t.Errorf("readPSI(%s) = %+v, %v; want %+v", tt.resource, got, ok, tt.want)
+		}
+	}
+	if _, ok := readPSI(t.TempDir(), "memory"); ok {
+		t.Error("kernel without PSI should report ok=false")
+	}
+}
+
+func TestParsePSIOlderKernelWithoutFull(t *testing.T) {
+	// Before 5.13 /proc/pressure/cpu had only the "some" line.
+	got, ok := parsePSI("some avg10=0.42 avg60=0.10 avg300=0.01 total=1000\n")
+	if !ok || got.Some10 != 0.42 || got.Full10 != 0 {
+		t.Errorf("parsePSI = %+v, %v", got, ok)
+	}
+	if _, ok := parsePSI("garbage\n"); ok {
+		t.Error("unparseable PSI should report ok=false")
+	}
+}
+
+func TestPSIMemoryLevel(t *testing.T) {
+	tests := []struct {
+		stat psiStat
+		want string
+	}{
+		{psiStat{}, "normal"},
+		{psiStat{Some10: 9.9}, "normal"},
+		{psiStat{Some10: 12.5, Full10: 3.25}, "warn"},
+		{psiStat{Some10: 45}, "critical"},
+		{psiStat{Some10: 15, Full10: 10}, "critical"},
+	}
+	for _, tt := range tests {
+		if got := psiMemoryLevel(tt.stat); got != tt.want {
+			t.Errorf("psiMemoryLevel(%+v) = %q, want %q", tt.stat, got, tt.want)
+		}
+	}
+}
+
+func TestReadMeminfoReclaimableCache(t *testing.T) {
+	info := readMeminfo(testProcRoot)
+	if info["MemTotal"] != 16265216*1024 {
+		t.Errorf("MemTotal = %d", info["MemTotal"])
+	}
+	if _, ok := info["HugePages_Total"]; !ok {
+		t.Error("unitless fields should still be parsed")
+	}
+	// Cached + Buffers + SReclaimable - Shmem.
+	want := uint64(7340032+412300+786432-524288) * 1024
+	if got := reclaimableCache(info); got != want {
+		t.Errorf("reclaimableCache = %d, want %d", got, want)
+	}
+	if got := reclaimableCache(map[string]uint64{"Shmem": 10}); got != 0 {
+		t.Errorf("shmem larger than cache = %d, want 0", got)
+	}
+}
+
+func TestStallShownOnCards(t *testing.T) {
+	cpu := renderCPUCard(CPUStatus{Usage: 50, LogicalCPU: 8, Stall: 7.8}, ThermalStatus{}, CgroupStatus{})
+	if last := stripANSI(cpu.lines[len(cpu.lines)-1]); !strings.HasPrefix(last, "Stall") || !strings.HasSuffix(last, "7.8%") {
+		t.Errorf("CPU stall line = %q", last)
+	}
+	disk := renderDiskCard(nil, DiskIOStatus{Stall: 2.1})
+	if last := stripANSI(disk.lines[len(disk.lines)-1]); !strings.HasSuffix(last, "2.1%") {
+		t.Errorf("disk stall line = %q", last)
+	}
+	if lines := renderDiskCard(nil, DiskIOStatus{}).lines; strings.Contains(stripANSI(strings.Join(lines, "\n")), "Stall") {
+		t.Error("stall line shown without PSI data")
+	}
+	mem := renderMemoryCard(MemoryStatus{Total: 100, Used: 50, UsedPercent: 50, Pressure: "warn", Stall: 12.5}, CgroupStatus{})
+	if last := stripANSI(mem.lines[len(mem.lines)-1]); last != "Status warn · 12.5% stalled" {
+		t.Errorf("memory status line = %q", last)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	probeInterval    = 10 * time.Second
+	probeTimeout     = 3 * time.Second
+	probeHistorySize = 30 // Samples kept per probe for latency, jitter and loss
+)
+
+// probeSpecs are the connectivity checks run by the probes source (--probes).
+var probeSpecs = mustParseProbes("tcp://1.1.1.1:443,dns://one.one.one.one")
+
+// probeSpec is one connectivity check.
+type probeSpec struct {
+	Kind   string // tcp, dns or http
+	Target string // host:port, hostname or URL
+}
+
+func (p probeSpec) String() string {
+	if p.Kind == "http" {
+		return p.Target
+	}
+	return p.Kind + "://" + p.Target
+}
+
+// probeSample is one probe run; failed runs carry no latency.
+type probeSample struct {
+	latency float64 // ms
+	ok      bool
+}
+
+// parseProbes parses a comma-separated probe list such as
+// "tcp://1.1.1.1:443,dns://example.com,https://example.com". "none" disables
+// probing.
+func parseProbes(raw string) ([]probeSpec, error) {
+	var specs []probeSpec
+	for item := range strings.SplitSeq(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" || item == "none" {
+			continue
+		}
+		scheme, target, ok := strings.Cut(item, "://")
+		if !ok || target == "" {
+			return nil, fmt.Errorf("probe %q: want tcp://host:port, dns://name or http(s)://url", item)
+		}
+		switch scheme {
+		case "tcp":
+			if _, _, err := net.SplitHostPort(target); err != nil {
+				return nil, fmt.Errorf("probe %q: %w", item, err)
+			}
+			specs = append(specs, probeSpec{Kind: "tcp", Target: target})
+		case "dns":
+			specs = append(specs, probeSpec{Kind: "dns", Target: target})
+		case "http", "https":
+			specs = append(specs, probeSpec{Kind: "http", Target: item})
+		default:
+			return nil, fmt.Errorf("probe %q: unknown kind %q", item, scheme)
+		}
+	}
+	return specs, nil
+}
+
+func mustParseProbes(raw string) []probeSpec {
+	specs, err := parseProbes(raw)
+	if err != nil {
+		panic(err)
+	}
+	return specs
+}
+
+// collectProbes runs every probe concurrently and summarizes each one's
+// recent history.
+func (c *Collector) collectProbes(ctx context.Context, specs []probeSpec) []ProbeStatus {
+	if len(specs) == 0 {
+		return nil
+	}
+	samples := make([]probeSample, len(specs))
+	errs := make([]error, len(specs))
+	var wg sync.WaitGroup
+	for i, spec := range specs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			latency, err := runProbe(ctx, spec)
+			samples[i] = probeSample{latency: float64(latency.Microseconds()) / 1000, ok: err == nil}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+
+	if c.probeHistory == nil {
+		c.probeHistory = make(map[string][]probeSample)
+	}
+	result := make([]ProbeStatus, 0, len(specs))
+	for i, spec := range specs {
+		key := spec.String()
+		history := append(c.probeHistory[key], samples[i])
+		if len(history) > probeHistorySize {
+			history = history[len(history)-probeHistorySize:]
+		}
+		c.probeHistory[key] = history
+
+		status := summarizeProbe(spec, history)
+		if errs[i] != nil {
+			status.LastError = errs[i].Error()
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// runProbe runs one check and returns how long it took.
+func runProbe(ctx context.Context, spec probeSpec) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	switch spec.Kind {
+	case "tcp":
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", spec.Target)
+		if err != nil {
+			return 0, err
+		}
+		elapsed := time.Since(start)
+		conn.Close()
+		return elapsed, nil
+	case "dns":
+		addrs, err := net.DefaultResolver.LookupHost(ctx, spec.Target)
+		if err != nil {
+			return 0, err
+		}
+		if len(addrs) == 0 {
+			return 0, errors.New("no addresses")
+		}
+		return time.Since(start), nil
+	case "http":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, spec.Target, nil)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		elapsed := time.Since(start)
+		// Any answer proves connectivity except the server failing outright.
+		if resp.StatusCode >= 500 {
+			return 0, fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+		return elapsed, nil
+	}
+	return 0, fmt.Errorf("unknown probe kind %q", spec.Kind)
+}
+
+// summarizeProbe computes latency, jitter and loss over a probe's history.
+// Jitter is the mean difference between consecutive successful samples.
+func summarizeProbe(spec probeSpec, history []probeSample) ProbeStatus {
+	status := ProbeStatus{Name: spec.String(), Kind: spec.Kind}
+	var (
+		ok, failed int
+		sum        float64
+		diffs      float64
+		prev       = -1.0
+	)
+	for _, s := range history {
+		if !s.ok {
+			failed++
+			status.History = append(status.History, 0)
+			continue
+		}
+		ok++
+		sum += s.latency
+		if prev >= 0 {
+			diffs += math.Abs(s.latency - prev)
+		}
+		prev = s.latency
+		status.History = append(status.History, s.latency)
+	}
+	if len(history) > 0 {
+		last := history[len(history)-1]
+		status.Up = last.ok
+		status.Latency = last.latency
+		status.FailureRate = float64(failed) / float64(len(history)) * 100
+	}
+	if ok > 0 {
+		status.AvgLatency = sum / float64(ok)
+	}
+	if ok > 1 {
+		status.Jitter = diffs / float64(ok-1)
+	}
+	return status
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseProbes(t *testing.T) {
+	specs, err := parseProbes("tcp://10.0.0.1:22, dns://example.com,https://example.com/health,none")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []probeSpec{
+		{Kind: "tcp", Target: "10.0.0.1:22"},
+		{Kind: "dns", Target: "example.com"},
+		{Kind: "http", Target: "https://example.com/health"},
+	}
+	if len(specs) != len(want) {
+		t.Fatalf("specs = %+v", specs)
+	}
+	for i := range want {
+		if specs[i] != want[i] {
+			t.Errorf("spec %d = %+v, want %+v", i, specs[i], want[i])
+		}
+	}
+	if specs[0].String() != "tcp://10.0.0.1:22" || specs[2].String() != "https://example.com/health" {
+		t.Errorf("names = %s, %s", specs[0], specs[2])
+	}
+
+	for _, bad := range []string{"tcp://10.0.0.1", "icmp://10.0.0.1", "example.com"} {
+		if _, err := parseProbes(bad); err == nil {
+			t.Errorf("parseProbes(%q) should fail", bad)
+		}
+	}
+	if specs, err := parseProbes("none"); err != nil || len(specs) != 0 {
+		t.Errorf("none = %+v, %v", specs, err)
+	}
+}
+
+func TestRunProbeAgainstLocalListeners(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	// A port nothing listens on: bind one, then close it.
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer healthy.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+
+	tests := []struct {
+		spec probeSpec
+		ok   bool
+	}{
+		{probeSpec{Kind: "tcp", Target: ln.Addr().String()}, true},
+		{probeSpec{Kind: "tcp", Target: closedAddr}, false},
+		{probeSpec{Kind: "dns", Target: "localhost"}, true},
+		{probeSpec{Kind: "http", Target: healthy.URL}, true},
+		{probeSpec{Kind: "http", Target: broken.URL}, false},
+	}
+	for _, tt := range tests {
+		_, err := runProbe(context.Background(), tt.spec)
+		if (err == nil) != tt.ok {
+			t.Errorf("runProbe(%s) err = %v, want ok=%v", tt.spec, err, tt.ok)
+		}
+	}
+}
+
+func TestCollectProbesKeepsHistory(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	spec := probeSpec{Kind: "tcp", Target: addr}
+	c := &Collector{}
+
+	// The listener accepts into its backlog without an Accept loop.
+	c.collectProbes(context.Background(), []probeSpec{spec})
+	ln.Close()
+	got := c.collectProbes(context.Background(), []probeSpec{spec})
+
+	if len(got) != 1 || len(got[0].History) != 2 {
+		t.Fatalf("probes = %+v", got)
+	}
+	p := got[0]
+	if p.Up || p.FailureRate != 50 || p.LastError == "" || p.Name != "tcp://"+addr {
+		t.Errorf("probe after listener closed = %+v", p)
+	}
+}
+
+func TestSummarizeProbe(t *testing.T) {
+	history := []probeSample{{latency: 10, ok: true}, {ok: false}, {latency: 30, ok: true}, {latency: 20, ok: true}}
+	got := summarizeProbe(probeSpec{Kind: "dns", Target: "example.com"}, history)
+
+	if !got.Up || got.Latency != 20 || got.AvgLatency != 20 || got.FailureRate != 25 {
+		t.Errorf("summary = %+v", got)
+	}
+	// |30-10| and |20-30| across the failed run.
+	if got.Jitter != 15 {
+		t.Errorf("Jitter = %v, want 15", got.Jitter)
+	}
+	if len(got.History) != 4 || got.History[1] != 0 {
+		t.Errorf("History = %v", got.History)
+	}
+}
+
+func TestProbeLines(t *testing.T) {
+	up := stripANSI(formatProbeLine(ProbeStatus{Name: "tcp://1.1.1.1:443", Up: true, Latency: 12.3, Jitter: 2, FailureRate: 10, History: []float64{10, 12}}, 8))
+	if !strings.HasPrefix(up, "✓ 1.1.1.1:443") || !strings.HasSuffix(up, "12ms ±2 · 10% failed") {
+		t.Errorf("up line = %q", up)
+	}
+	down := stripANSI(formatProbeLine(ProbeStatus{Name: "https://example.com", FailureRate: 100}, 8))
+	if !strings.HasPrefix(down, "✕ https://example") || !strings.HasSuffix(down, "down · 100% failed") {
+		t.Errorf("down line = %q", down)
+	}
+}
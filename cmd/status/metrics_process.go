@@ -2,52 +2,146 @@ package main
 
 import (
 	"context"
-	"runtime"
+	"sort"
 	"strconv"
-	"strings"
 	"time"
 )
 
-func collectTopProcesses() []ProcessInfo {
-	if runtime.GOOS != "darwin" {
-		return nil
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+const (
+	topProcessCount = 5
+	processTimeout  = 2 * time.Second
+)
+
+// procSample holds the counters needed to turn cumulative values into rates.
+type procSample struct {
+	cpuTotal   float64 // user+system seconds
+	readBytes  uint64
+	writeBytes uint64
+	netRx      uint64
+	netTx      uint64
+}
+
+func (c *Collector) collectProcesses(ctx context.Context, now time.Time) ([]ProcessInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, processTimeout)
 	defer cancel()
 
-	// Use ps to get top processes by CPU.
-	out, err := runCmd(ctx, "ps", "-Aceo", "pcpu,pmem,comm", "-r")
+	plat := platformFrom(ctx)
+	procs, err := plat.processes(ctx)
 	if err != nil {
-		return nil
+		return nil, err
+	}
+
+	var totalMem uint64
+	if vm, err := plat.virtualMemory(ctx); err == nil {
+		totalMem = vm.Total
 	}
 
-	var procs []ProcessInfo
-	i := 0
-	for line := range strings.Lines(strings.TrimSpace(out)) {
-		if i == 0 {
-			i++
+	elapsed := 0.0
+	if !c.lastProcAt.IsZero() {
+		elapsed = now.Sub(c.lastProcAt).Seconds()
+	}
+
+	netCounters := readSocketCounters(ctx)
+
+	samples := make(map[int32]procSample, len(procs))
+	result := make([]ProcessInfo, 0, len(procs))
+	for _, p := range procs {
+		if err := ctx.Err(); err != nil {
+			// A partial list would look like processes exiting; keep the
+			// last full one instead.
+			return nil, err
+		}
+		name, err := p.NameWithContext(ctx)
+		if err != nil || name == "" {
+			// Process exited or is not inspectable.
 			continue
 		}
-		if i > 5 {
-			break
+		info := ProcessInfo{PID: p.PID(), Name: name}
+		info.PPID, _ = p.PpidWithContext(ctx)
+		info.User = c.processUser(ctx, p)
+		info.Threads, _ = p.NumThreadsWithContext(ctx)
+		info.OpenFiles, _ = p.NumFDsWithContext(ctx)
+
+		if mi, err := p.MemoryInfoWithContext(ctx); err == nil && mi != nil {
+			info.RSS = mi.RSS
+			if totalMem > 0 {
+				info.Memory = float64(mi.RSS) / float64(totalMem) * 100
+			}
 		}
-		i++
-		fields := strings.Fields(line)
-		if len(fields) < 3 {
-			continue
+
+		var sample procSample
+		if times, err := p.TimesWithContext(ctx); err == nil && times != nil {
+			sample.cpuTotal = times.User + times.System
+		}
+		// IO counters are unavailable on macOS and for other users' processes.
+		if io, err := p.IOCountersWithContext(ctx); err == nil && io != nil {
+			sample.readBytes = io.ReadBytes
+			sample.writeBytes = io.WriteBytes
+			if plat.goos == "linux" {
+				// rchar/wchar count every read/write call, cache hits and
+				// pipes included; read_bytes/write_bytes reached storage.
+				sample.readBytes = io.DiskReadBytes
+				sample.writeBytes = io.DiskWriteBytes
+			}
 		}
-		cpuVal, _ := strconv.ParseFloat(fields[0], 64)
-		memVal, _ := strconv.ParseFloat(fields[1], 64)
-		name := fields[len(fields)-1]
-		// Strip path from command name.
-		if idx := strings.LastIndex(name, "/"); idx >= 0 {
-			name = name[idx+1:]
+		if nc, ok := netCounters[p.PID()]; ok {
+			sample.netRx, sample.netTx = nc.rx, nc.tx
+		}
+		samples[p.PID()] = sample
+
+		if prev, ok := c.prevProcs[p.PID()]; ok && elapsed > 0 {
+			info.CPU = max((sample.cpuTotal-prev.cpuTotal)/elapsed*100, 0)
+			info.ReadRate = counterRate(prev.readBytes, sample.readBytes, elapsed)
+			info.WriteRate = counterRate(prev.writeBytes, sample.writeBytes, elapsed)
+			info.NetRxRate = counterRate(prev.netRx, sample.netRx, elapsed)
+			info.NetTxRate = counterRate(prev.netTx, sample.netTx, elapsed)
+		}
+		result = append(result, info)
+	}
+
+	c.prevProcs = samples
+	c.lastProcAt = now
+	return result, nil
+}
+
+// processUser resolves the owner of a process, caching uid lookups.
+func (c *Collector) processUser(ctx context.Context, p hostProcess) string {
+	uids, err := p.UidsWithContext(ctx)
+	if err != nil || len(uids) == 0 {
+		return ""
+	}
+	uid := uids[0]
+	if name, ok := c.userNames[uid]; ok {
+		return name
+	}
+	name, err := p.UsernameWithContext(ctx)
+	if err != nil || name == "" {
+		name = strconv.FormatUint(uint64(uid), 10)
+	}
+	c.userNames[uid] = name
+	return name
+}
+
+// counterRate converts two cumulative byte counters into MiB/s.
+func counterRate(prev, cur uint64, elapsed float64) float64 {
+	if cur < prev || elapsed <= 0 {
+		return 0
+	}
+	return float64(cur-prev) / 1024 / 1024 / elapsed
+}
+
+// topProcesses returns the busiest processes by CPU for the dashboard card.
+func topProcesses(procs []ProcessInfo, n int) []ProcessInfo {
+	top := make([]ProcessInfo, len(procs))
+	copy(top, procs)
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].CPU != top[j].CPU {
+			return top[i].CPU > top[j].CPU
 		}
-		procs = append(procs, ProcessInfo{
-			Name:   name,
-			CPU:    cpuVal,
-			Memory: memVal,
-		})
+		return top[i].Memory > top[j].Memory
+	})
+	if len(top) > n {
+		top = top[:n]
 	}
-	return procs
+	return top
 }
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	topIOCount    = 5
+	socketTimeout = 1500 * time.Millisecond
+)
+
+// socketCounters holds cumulative socket bytes for one process.
+type socketCounters struct {
+	rx uint64
+	tx uint64
+}
+
+// readSocketCounters returns cumulative per-process socket bytes from
+// nettop on macOS and ss on Linux, or nil when neither is available.
+func readSocketCounters(ctx context.Context) map[int32]socketCounters {
+	switch platformFrom(ctx).goos {
+	case "darwin":
+		return readNettopCounters(ctx)
+	case "linux":
+		return readSSCounters(ctx)
+	}
+	return nil
+}
+
+// readNettopCounters returns cumulative per-process socket bytes on macOS.
+func readNettopCounters(ctx context.Context) map[int32]socketCounters {
+	if !commandExists(ctx, "nettop") {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, socketTimeout)
+	defer cancel()
+	out, err := runCmd(ctx, "nettop", "-P", "-L", "1", "-x", "-J", "bytes_in,bytes_out")
+	if err != nil {
+		return nil
+	}
+	return parseNettop(out)
+}
+
+// parseNettop parses nettop CSV rows such as "12:00:01.5,Safari.812,1024,512,".
+func parseNettop(raw string) map[int32]socketCounters {
+	result := make(map[int32]socketCounters)
+	for line := range strings.Lines(raw) {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		for i, f := range fields {
+			dot := strings.LastIndex(f, ".")
+			if dot <= 0 || i+2 >= len(fields) {
+				continue
+			}
+			pid, err := strconv.ParseInt(f[dot+1:], 10, 32)
+			if err != nil || pid <= 0 {
+				continue
+			}
+			rx, errRx := strconv.ParseUint(strings.TrimSpace(fields[i+1]), 10, 64)
+			tx, errTx := strconv.ParseUint(strings.TrimSpace(fields[i+2]), 10, 64)
+			if errRx != nil || errTx != nil {
+				continue
+			}
+			result[int32(pid)] = socketCounters{rx: rx, tx: tx}
+			break
+		}
+	}
+	return result
+}
+
+// readSSCounters returns per-process TCP bytes on Linux from the kernel's
+// per-socket counters. Only open sockets count, and without root ss names
+// the owners of the caller's sockets only.
+func readSSCounters(ctx context.Context) map[int32]socketCounters {
+	if !commandExists(ctx, "ss") {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, socketTimeout)
+	defer cancel()
+	out, err := runCmd(ctx, "ss", "-tinpH")
+	if err != nil {
+		return nil
+	}
+	return parseSS(out)
+}
+
+// parseSS sums the bytes_received and bytes_acked counters of "ss -tinpH"
+// by owning process. Each socket line, ending in
+// users:(("curl",pid=4242,fd=5)), is followed by an indented line of TCP
+// info. A socket shared by several processes counts for the first.
+func parseSS(raw string) map[int32]socketCounters {
+	result := make(map[int32]socketCounters)
+	pid := int32(-1)
+	for line := range strings.Lines(raw) {
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			pid = -1
+			if _, after, ok := strings.Cut(line, ",pid="); ok {
+				end := strings.IndexFunc(after, func(r rune) bool { return r < '0' || r > '9' })
+				if end > 0 {
+					if n, err := strconv.ParseInt(after[:end], 10, 32); err == nil {
+						pid = int32(n)
+					}
+				}
+			}
+			continue
+		}
+		if pid <= 0 {
+			continue
+		}
+		c := result[pid]
+		var sent, acked uint64
+		for _, field := range strings.Fields(line) {
+			key, value, ok := strings.Cut(field, ":")
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "bytes_received":
+				c.rx += n
+			case "bytes_acked":
+				acked = n
+			case "bytes_sent":
+				sent = n
+			}
+		}
+		// bytes_acked leaves out retransmits; older kernels only have bytes_sent.
+		if acked > 0 {
+			c.tx += acked
+		} else {
+			c.tx += sent
+		}
+		result[pid] = c
+	}
+	return result
+}
+
+// topIOProcesses returns the processes moving the most bytes across disk and network.
+func topIOProcesses(procs []ProcessInfo, n int) []ProcessInfo {
+	var busy []ProcessInfo
+	for _, p := range procs {
+		if p.totalIORate() > 0 {
+			busy = append(busy, p)
+		}
+	}
+	sort.SliceStable(busy, func(i, j int) bool {
+		return busy[i].totalIORate() > busy[j].totalIORate()
+	})
+	if len(busy) > n {
+		busy = busy[:n]
+	}
+	return busy
+}
+
+func (p ProcessInfo) totalIORate() float64 {
+	return p.ReadRate + p.WriteRate + p.NetRxRate + p.NetTxRate
+}
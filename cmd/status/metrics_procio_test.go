@@ -0,0 +1,60 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseNettop(t *testing.T) {
+	raw := `time,,bytes_in,bytes_out,
+17:46:04.154593,apsd.370,2236,7618,
+17:46:04.154600,Google Chrome H.812,1048576,4096,
+17:46:04.154611,garbage,1,2,
+`
+	got := parseNettop(raw)
+	if len(got) != 2 {
+		t.Fatalf("parseNettop returned %d entries, want 2: %v", len(got), got)
+	}
+	if got[370] != (socketCounters{rx: 2236, tx: 7618}) {
+		t.Errorf("pid 370 = %+v", got[370])
+	}
+	if got[812] != (socketCounters{rx: 1048576, tx: 4096}) {
+		t.Errorf("pid 812 = %+v", got[812])
+	}
+}
+
+func TestParseSS(t *testing.T) {
+	raw := "ESTAB 0 0 10.0.0.5:22 10.0.0.9:51234 users:((\"sshd\",pid=812,fd=4),(\"sshd\",pid=900,fd=4))\n" +
+		"\t cubic wscale:7,7 rto:204 bytes_sent:5000 bytes_retrans:200 bytes_acked:4800 bytes_received:1200 segs_out:40\n" +
+		"ESTAB 0 0 10.0.0.5:40000 1.1.1.1:443 users:((\"curl\",pid=4242,fd=5))\n" +
+		"\t cubic bytes_sent:300 bytes_received:2048\n" +
+		"ESTAB 0 0 10.0.0.5:40001 1.1.1.1:443 users:((\"curl\",pid=4242,fd=6))\n" +
+		"\t cubic bytes_acked:100 bytes_received:1024\n" +
+		// Another user's socket: no owner without root.
+		"ESTAB 0 0 10.0.0.5:40002 1.1.1.1:443\n" +
+		"\t cubic bytes_acked:999 bytes_received:999\n"
+	got := parseSS(raw)
+	want := map[int32]socketCounters{
+		812:  {rx: 1200, tx: 4800},
+		4242: {rx: 3072, tx: 400},
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("parseSS = %+v, want %+v", got, want)
+	}
+}
+
+func TestTopIOProcesses(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 1, Name: "idle"},
+		{PID: 2, Name: "rsync", ReadRate: 50, WriteRate: 40},
+		{PID: 3, Name: "curl", NetRxRate: 120},
+		{PID: 4, Name: "mds", WriteRate: 2},
+	}
+	got := topIOProcesses(procs, 2)
+	if len(got) != 2 || got[0].PID != 3 || got[1].PID != 2 {
+		t.Errorf("topIOProcesses = %+v, want curl then rsync", got)
+	}
+	if all := topIOProcesses(procs, 10); len(all) != 3 {
+		t.Errorf("idle processes should be excluded, got %d entries", len(all))
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	unitCelsius = "°C"
+	unitRPM     = "RPM"
+	unitWatts   = "W"
+)
+
+// Chips and thermal zones that report the CPU package temperature, best first.
+var cpuTempSources = []string{"coretemp", "k10temp", "zenpower", "cpu_thermal", "x86_pkg_temp", "soc_thermal", "acpitz"}
+
+// Chips that report GPU temperature.
+var gpuTempSources = []string{"amdgpu", "nouveau", "radeon", "i915", "xe"}
+
+// collectSensors reads temperature, fan and power sensors on Linux.
+func collectSensors(ctx context.Context) ([]SensorReading, error) {
+	p := platformFrom(ctx)
+	if p.goos != "linux" {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return readSysfsSensors(p.sysRoot), nil
+}
+
+// readSysfsSensors reads hwmon chips and thermal zones under root. Note holds
+// the chip or zone type so readings can be attributed.
+func readSysfsSensors(root string) []SensorReading {
+	var readings []SensorReading
+	chips := make(map[string]bool)
+
+	hwmons, _ := filepath.Glob(filepath.Join(root, "class", "hwmon", "hwmon*"))
+	sort.Strings(hwmons)
+	for _, dir := range hwmons {
+		// Older drivers keep their attributes under device/.
+		if _, err := os.Stat(filepath.Join(dir, "name")); err != nil {
+			dir = filepath.Join(dir, "device")
+		}
+		chip := readSysString(filepath.Join(dir, "name"))
+		if chip == "" {
+			continue
+		}
+		chips[chip] = true
+		readings = append(readings, readHwmonChip(dir, chip)...)
+	}
+
+	zones, _ := filepath.Glob(filepath.Join(root, "class", "thermal", "thermal_zone*"))
+	sort.Strings(zones)
+	for _, dir := range zones {
+		zone := strings.ReplaceAll(readSysString(filepath.Join(dir, "type")), "-", "_")
+		// Zones bound to hwmon already appear there under the same name.
+		if zone == "" || chips[zone] {
+			continue
+		}
+		milli, ok := readSysInt(filepath.Join(dir, "temp"))
+		if !ok || milli <= 0 {
+			continue
+		}
+		readings = append(readings, SensorReading{Label: zone, Value: float64(milli) / 1000, Unit: unitCelsius, Note: zone})
+	}
+	return readings
+}
+
+// readHwmonChip reads tempN_input (m°C), fanN_input (RPM) and powerN_input or
+// powerN_average (µW) from one hwmon directory.
+func readHwmonChip(dir, chip string) []SensorReading {
+	inputs, _ := filepath.Glob(filepath.Join(dir, "*_input"))
+	averages, _ := filepath.Glob(filepath.Join(dir, "power*_average"))
+	files := append(inputs, averages...)
+	sort.Strings(files)
+
+	var readings []SensorReading
+	seen := make(map[string]bool)
+	for _, file := range files {
+		base := filepath.Base(file)
+		sensor, _, _ := strings.Cut(base, "_")
+		if seen[sensor] {
+			continue
+		}
+		raw, ok := readSysInt(file)
+		if !ok {
+			continue
+		}
+
+		var r SensorReading
+		switch {
+		case strings.HasPrefix(sensor, "temp"):
+			if raw <= 0 {
+				continue
+			}
+			r = SensorReading{Value: float64(raw) / 1000, Unit: unitCelsius}
+		case strings.HasPrefix(sensor, "fan"):
+			r = SensorReading{Value: float64(raw), Unit: unitRPM}
+		case strings.HasPrefix(sensor, "power"):
+			r = SensorReading{Value: float64(raw) / 1e6, Unit: unitWatts}
+		default:
+			continue // Voltages, currents and the like.
+		}
+		seen[sensor] = true
+		r.Note = chip
+		r.Label = chip + " " + sensor
+		if label := readSysString(filepath.Join(dir, sensor+"_label")); label != "" {
+			r.Label = chip + " " + label
+		}
+		readings = append(readings, r)
+	}
+	return readings
+}
+
+// thermalFromSensors derives the CPU/GPU temperatures and fan speed shown on
+// the CPU and power cards.
+func thermalFromSensors(readings []SensorReading) ThermalStatus {
+	var thermal ThermalStatus
+	thermal.CPUTemp = pickTemp(readings, cpuTempSources)
+	thermal.GPUTemp = pickTemp(readings, gpuTempSources)
+	for _, r := range readings {
+		if r.Unit != unitRPM {
+			continue
+		}
+		thermal.FanCount++
+		thermal.FanSpeed = max(thermal.FanSpeed, int(r.Value))
+	}
+	return thermal
+}
+
+// pickTemp returns the hottest reading of the first source present.
+func pickTemp(readings []SensorReading, sources []string) float64 {
+	for _, src := range sources {
+		hottest := 0.0
+		for _, r := range readings {
+			if r.Unit == unitCelsius && r.Note == src {
+				hottest = max(hottest, r.Value)
+			}
+		}
+		if hottest > 0 {
+			return hottest
+		}
+	}
+	return 0
+}
+
+func hasSensorData(readings []SensorReading) bool {
+	return len(readings) > 0
+}
+
+func readSysString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func readSysInt(path string) (int64, bool) {
+	v, err := strconv.ParseInt(readSysString(path), 10, 64)
+	return v, err == nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSysfs creates files under root from a path -> content map.
+func writeSysfs(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func fakeSensorSysfs(t *testing.T) string {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"class/hwmon/hwmon0/name":                  "acpitz",
+		"class/hwmon/hwmon0/temp1_input":           "27800",
+		"class/hwmon/hwmon1/name":                  "coretemp",
+		"class/hwmon/hwmon1/temp1_input":           "61000",
+		"class/hwmon/hwmon1/temp1_label":           "Package id 0",
+		"class/hwmon/hwmon1/temp2_input":           "58000",
+		"class/hwmon/hwmon1/temp2_label":           "Core 0",
+		"class/hwmon/hwmon1/in0_input":             "900",
+		"class/hwmon/hwmon2/name":                  "thinkpad",
+		"class/hwmon/hwmon2/fan1_input":            "2400",
+		"class/hwmon/hwmon2/fan2_input":            "0",
+		"class/hwmon/hwmon3/device/name":           "amdgpu",
+		"class/hwmon/hwmon3/device/temp1_input":    "48000",
+		"class/hwmon/hwmon3/device/temp1_label":    "edge",
+		"class/hwmon/hwmon3/device/power1_average": "15250000",
+		"class/thermal/thermal_zone0/type":         "acpitz",
+		"class/thermal/thermal_zone0/temp":         "27800",
+		"class/thermal/thermal_zone1/type":         "x86_pkg_temp",
+		"class/thermal/thermal_zone1/temp":         "62000",
+		"class/thermal/thermal_zone2/type":         "iwlwifi_1",
+		"class/thermal/thermal_zone2/temp":         "-274000",
+	})
+	return root
+}
+
+func TestReadSysfsSensors(t *testing.T) {
+	readings := readSysfsSensors(fakeSensorSysfs(t))
+
+	got := make(map[string]SensorReading)
+	for _, r := range readings {
+		got[r.Label] = r
+	}
+	want := map[string]SensorReading{
+		"acpitz temp1":          {Value: 27.8, Unit: unitCelsius, Note: "acpitz"},
+		"coretemp Package id 0": {Value: 61, Unit: unitCelsius, Note: "coretemp"},
+		"coretemp Core 0":       {Value: 58, Unit: unitCelsius, Note: "coretemp"},
+		"thinkpad fan1":         {Value: 2400, Unit: unitRPM, Note: "thinkpad"},
+		"thinkpad fan2":         {Value: 0, Unit: unitRPM, Note: "thinkpad"},
+		"amdgpu edge":           {Value: 48, Unit: unitCelsius, Note: "amdgpu"},
+		"amdgpu power1":         {Value: 15.25, Unit: unitWatts, Note: "amdgpu"},
+		"x86_pkg_temp":          {Value: 62, Unit: unitCelsius, Note: "x86_pkg_temp"},
+	}
+	if len(readings) != len(want) {
+		t.Errorf("got %d readings, want %d: %+v", len(readings), len(want), readings)
+	}
+	for label, w := range want {
+		r, ok := got[label]
+		w.Label = label
+		if !ok || r != w {
+			t.Errorf("reading %q = %+v, want %+v", label, r, w)
+		}
+	}
+}
+
+func TestThermalFromSensors(t *testing.T) {
+	thermal := thermalFromSensors(readSysfsSensors(fakeSensorSysfs(t)))
+	if thermal.CPUTemp != 61 {
+		t.Errorf("CPUTemp = %v, want coretemp package 61", thermal.CPUTemp)
+	}
+	if thermal.GPUTemp != 48 {
+		t.Errorf("GPUTemp = %v, want 48", thermal.GPUTemp)
+	}
+	if thermal.FanSpeed != 2400 || thermal.FanCount != 2 {
+		t.Errorf("fans = %d RPM x%d, want 2400 x2", thermal.FanSpeed, thermal.FanCount)
+	}
+
+	// Without a CPU chip, fall back to the thermal zone.
+	zoneOnly := []SensorReading{{Label: "x86_pkg_temp", Value: 70, Unit: unitCelsius, Note: "x86_pkg_temp"}}
+	if got := thermalFromSensors(zoneOnly).CPUTemp; got != 70 {
+		t.Errorf("zone fallback CPUTemp = %v", got)
+	}
+	if got := thermalFromSensors(nil); got != (ThermalStatus{}) {
+		t.Errorf("empty sysfs = %+v", got)
+	}
+}
+
+// On Linux the thermal summary comes from the readings the sensors card
+// shows, not a second walk of sysfs.
+func TestCollectThermalUsesSensors(t *testing.T) {
+	ctx := withPlatform(context.Background(), &platform{goos: "linux", sysRoot: t.TempDir()})
+	sensors := []SensorReading{{Label: "Package id 0", Value: 55, Unit: unitCelsius, Note: "coretemp"}}
+	if got := collectThermal(ctx, sensors).CPUTemp; got != 55 {
+		t.Errorf("CPUTemp = %v, want 55", got)
+	}
+}
+
+func TestRenderSensorsCard(t *testing.T) {
+	card := renderSensorsCard([]SensorReading{
+		{Label: "thinkpad fan1", Value: 2400, Unit: unitRPM},
+		{Label: "coretemp Core 0", Value: 58, Unit: unitCelsius},
+		{Label: "coretemp Package id 0", Value: 61, Unit: unitCelsius},
+	})
+	if len(card.lines) != 3 {
+		t.Fatalf("lines = %q", card.lines)
+	}
+	first := stripANSI(card.lines[0])
+	if !strings.HasPrefix(first, "coretemp Package id 0") || !strings.HasSuffix(first, "61.0°C") {
+		t.Errorf("hottest temperature should come first, got %q", first)
+	}
+	if last := stripANSI(card.lines[2]); !strings.HasSuffix(last, "2400 RPM") {
+		t.Errorf("fan line = %q", last)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	driveHealthInterval = time.Minute
+	smartctlTimeout     = 5 * time.Second
+
+	// Thresholds for drive health levels.
+	driveWearWarn = 90  // % of rated endurance used
+	driveTempWarn = 70  // Celsius
+	driveWearCrit = 100 // Endurance exhausted
+)
+
+// ATA attributes that report remaining life as a normalized value.
+var ataLifeAttributes = map[int]bool{
+	169: true, // Remaining_Lifetime_Perc
+	177: true, // Wear_Leveling_Count
+	202: true, // Percent_Lifetime_Remain
+	231: true, // SSD_Life_Left
+	233: true, // Media_Wearout_Indicator
+}
+
+// smartctlOutput is the subset of `smartctl --json -a` used here.
+type smartctlOutput struct {
+	Device struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"device"`
+	ModelName   string `json:"model_name"`
+	SmartStatus *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature struct {
+		Current float64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime struct {
+		Hours uint64 `json:"hours"`
+	} `json:"power_on_time"`
+	NVMeLog *struct {
+		PercentageUsed int    `json:"percentage_used"`
+		MediaErrors    uint64 `json:"media_errors"`
+	} `json:"nvme_smart_health_information_log"`
+	ATAAttributes struct {
+		Table []struct {
+			ID    int `json:"id"`
+			Value int `json:"value"`
+			Raw   struct {
+				Value uint64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+}
+
+// smartctlScan is the output of `smartctl --scan -j`.
+type smartctlScan struct {
+	Devices []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"devices"`
+}
+
+// collectDriveHealth reads SMART data for every drive smartctl can see,
+// falling back to NVMe sysfs attributes on Linux.
+func collectDriveHealth(ctx context.Context) ([]DriveHealth, error) {
+	p := platformFrom(ctx)
+	if !commandExists(ctx, "smartctl") {
+		if p.goos == "linux" {
+			return readNVMeSysfs(p.sysRoot), nil
+		}
+		return nil, nil
+	}
+
+	out, err := runSmartctl(ctx, "--scan", "-j")
+	if err != nil {
+		return nil, err
+	}
+	var scan smartctlScan
+	if err := json.Unmarshal([]byte(out), &scan); err != nil {
+		return nil, err
+	}
+
+	var drives []DriveHealth
+	for _, dev := range scan.Devices {
+		out, err := runSmartctl(ctx, "--json", "-a", "-d", dev.Type, dev.Name)
+		if err != nil {
+			continue // Usually needs root; skip rather than fail the rest.
+		}
+		if d, err := parseSmartctl(out); err == nil {
+			drives = append(drives, d)
+		}
+	}
+	if len(drives) == 0 && p.goos == "linux" {
+		return readNVMeSysfs(p.sysRoot), nil
+	}
+	return drives, nil
+}
+
+// runSmartctl runs smartctl, keeping its output when the exit status only
+// reports drive problems. Bits 0 and 1 mean the command itself failed.
+func runSmartctl(ctx context.Context, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, smartctlTimeout)
+	defer cancel()
+	out, err := runCmdOutput(ctx, "smartctl", args...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode()&0x3 == 0 && len(out) > 0 {
+		return out, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// parseSmartctl converts one device's `smartctl --json -a` output.
+func parseSmartctl(raw string) (DriveHealth, error) {
+	var out smartctlOutput
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return DriveHealth{}, err
+	}
+	if out.Device.Name == "" {
+		return DriveHealth{}, errors.New("smartctl output has no device")
+	}
+
+	d := DriveHealth{
+		Device:       out.Device.Name,
+		Model:        strings.TrimSpace(out.ModelName),
+		Temp:         out.Temperature.Current,
+		PercentUsed:  -1,
+		PowerOnHours: out.PowerOnTime.Hours,
+		Failed:       out.SmartStatus != nil && !out.SmartStatus.Passed,
+		Source:       "smartctl",
+	}
+	if out.NVMeLog != nil {
+		d.PercentUsed = out.NVMeLog.PercentageUsed
+		d.MediaErrors = out.NVMeLog.MediaErrors
+	}
+	for _, attr := range out.ATAAttributes.Table {
+		switch {
+		case attr.ID == 5:
+			d.Reallocated = attr.Raw.Value
+		case attr.ID == 187 || attr.ID == 198:
+			d.MediaErrors += attr.Raw.Value
+		case ataLifeAttributes[attr.ID] && d.PercentUsed < 0:
+			d.PercentUsed = max(0, 100-attr.Value)
+		}
+	}
+	return d, nil
+}
+
+// readNVMeSysfs reports model and temperature of NVMe controllers under
+// root/class/nvme. Wear and error counters need smartctl.
+func readNVMeSysfs(root string) []DriveHealth {
+	dirs, _ := filepath.Glob(filepath.Join(root, "class", "nvme", "nvme*"))
+	sort.Strings(dirs)
+	var drives []DriveHealth
+	for _, dir := range dirs {
+		d := DriveHealth{
+			Device:      "/dev/" + filepath.Base(dir),
+			Model:       readSysString(filepath.Join(dir, "model")),
+			PercentUsed: -1,
+			Source:      "sysfs",
+		}
+		// The hwmon node sits under the controller on newer kernels and
+		// under its PCI device on older ones.
+		hwmons, _ := filepath.Glob(filepath.Join(dir, "hwmon*"))
+		more, _ := filepath.Glob(filepath.Join(dir, "device", "hwmon", "hwmon*"))
+		for _, hw := range append(hwmons, more...) {
+			if milli, ok := readSysInt(filepath.Join(hw, "temp1_input")); ok && milli > 0 {
+				d.Temp = float64(milli) / 1000
+				break
+			}
+		}
+		drives = append(drives, d)
+	}
+	return drives
+}
+
+// Level grades a drive as "ok", "warn" or "critical".
+func (d DriveHealth) Level() string {
+	switch {
+	case d.Failed || d.MediaErrors > 0 || d.PercentUsed >= driveWearCrit:
+		return "critical"
+	case d.Reallocated > 0 || d.PercentUsed >= driveWearWarn || d.Temp >= driveTempWarn:
+		return "warn"
+	default:
+		return "ok"
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readSmartctlFixture(t *testing.T, name string) string {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "smartctl", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestParseSmartctl(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    DriveHealth
+	}{
+		{"nvme.json", DriveHealth{Device: "/dev/nvme0", Temp: 41, PercentUsed: 4, PowerOnHours: 6123}},
+		{"sata.json", DriveHealth{Device: "/dev/sda", Model: "CT500MX500SSD1", Temp: 34, PercentUsed: 9, PowerOnHours: 18211, Reallocated: 2}},
+		{"failing.json", DriveHealth{Device: "/dev/sdb", Temp: 39, PercentUsed: -1, PowerOnHours: 41022, Reallocated: 1864, MediaErrors: 17, Failed: true}},
+	}
+	for _, tt := range tests {
+		got, err := parseSmartctl(readSmartctlFixture(t, tt.fixture))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.fixture, err)
+		}
+		if tt.want.Model == "" {
+			tt.want.Model = got.Model
+		}
+		tt.want.Source = "smartctl"
+		if got != tt.want {
+			t.Errorf("%s:\n got %+v\nwant %+v", tt.fixture, got, tt.want)
+		}
+	}
+
+	if _, err := parseSmartctl(`{"smartctl": {"exit_status": 2}}`); err == nil {
+		t.Error("output without a device should fail")
+	}
+}
+
+func TestSmartctlScan(t *testing.T) {
+	var scan smartctlScan
+	if err := json.Unmarshal([]byte(readSmartctlFixture(t, "scan.json")), &scan); err != nil {
+		t.Fatal(err)
+	}
+	if len(scan.Devices) != 2 || scan.Devices[0].Type != "sat" || scan.Devices[1].Name != "/dev/nvme0" {
+		t.Errorf("devices = %+v", scan.Devices)
+	}
+}
+
+func TestReadNVMeSysfs(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"class/nvme/nvme0/model":              "Samsung SSD 980 PRO 1TB   ",
+		"class/nvme/nvme0/hwmon3/temp1_input": "38850",
+		"class/nvme/nvme1/model":              "WD Blue SN570",
+	})
+
+	drives := readNVMeSysfs(root)
+	if len(drives) != 2 {
+		t.Fatalf("drives = %+v", drives)
+	}
+	if d := drives[0]; d.Device != "/dev/nvme0" || d.Model != "Samsung SSD 980 PRO 1TB" || d.Temp != 38.85 || d.PercentUsed != -1 {
+		t.Errorf("nvme0 = %+v", d)
+	}
+	if d := drives[1]; d.Temp != 0 || d.Source != "sysfs" {
+		t.Errorf("nvme1 = %+v", d)
+	}
+}
+
+func TestDriveHealthLevel(t *testing.T) {
+	tests := []struct {
+		drive DriveHealth
+		want  string
+	}{
+		{DriveHealth{PercentUsed: -1}, "ok"},
+		{DriveHealth{PercentUsed: 89, Temp: 69}, "ok"},
+		{DriveHealth{PercentUsed: 90}, "warn"},
+		{DriveHealth{PercentUsed: -1, Temp: 72}, "warn"},
+		{DriveHealth{PercentUsed: -1, Reallocated: 2}, "warn"},
+		{DriveHealth{PercentUsed: 100}, "critical"},
+		{DriveHealth{PercentUsed: -1, MediaErrors: 1}, "critical"},
+		{DriveHealth{PercentUsed: 4, Failed: true}, "critical"},
+	}
+	for _, tt := range tests {
+		if got := tt.drive.Level(); got != tt.want {
+			t.Errorf("Level(%+v) = %q, want %q", tt.drive, got, tt.want)
+		}
+	}
+}
+
+func TestHealthScoreDrivePenalty(t *testing.T) {
+	healthy, _ := calculateHealthScore(CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{}, nil, nil)
+
+	worn := []DriveHealth{{PercentUsed: 4}, {PercentUsed: 95}}
+	score, msg := calculateHealthScore(CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{}, worn, nil)
+	if score != healthy-driveWarnPenalty || !strings.Contains(msg, "Drive Wear") {
+		t.Errorf("worn drive: score %d (healthy %d), msg %q", score, healthy, msg)
+	}
+
+	// Only the worst drive counts.
+	failing := append(worn, DriveHealth{PercentUsed: -1, Failed: true})
+	score, msg = calculateHealthScore(CPUStatus{}, MemoryStatus{}, nil, DiskIOStatus{}, ThermalStatus{}, failing, nil)
+	if score != healthy-driveCritPenalty || !strings.Contains(msg, "Drive Failing") {
+		t.Errorf("failing drive: score %d (healthy %d), msg %q", score, healthy, msg)
+	}
+}
+
+func TestDriveHealthCard(t *testing.T) {
+	card := renderDriveHealthCard([]DriveHealth{
+		{Device: "/dev/nvme0", Temp: 41, PercentUsed: 4, PowerOnHours: 6123},
+		{Device: "/dev/sdb", PercentUsed: -1, Reallocated: 1864, MediaErrors: 17, Failed: true},
+	})
+	out := stripANSI(strings.Join(card.lines, "\n"))
+	for _, want := range []string{"nvme0", "4% worn", "6.1k h on", "sdb", "SMART failed · 17 media errors · 1864 reallocated"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("card missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "worn") != 1 {
+		t.Errorf("unknown wear shown:\n%s", out)
+	}
+}
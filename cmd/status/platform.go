@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/mem"
+	"github.com/shirou/gopsutil/v4/net"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// platform is everything collectors learn about the machine: the OS, how
+// commands are found and run, where procfs, sysfs and cgroupfs are mounted,
+// and what gopsutil reports. Tests swap in canned output to exercise another
+// OS's collectors.
+type platform struct {
+	goos       string
+	goarch     string
+	run        func(ctx context.Context, name string, args ...string) ([]byte, error)
+	lookPath   func(name string) (string, error)
+	procRoot   string
+	sysRoot    string
+	cgroupRoot string
+
+	cpuCounts     func(ctx context.Context, logical bool) (int, error)
+	cpuTimes      func(ctx context.Context, perCPU bool) ([]cpu.TimesStat, error)
+	loadAvg       func(ctx context.Context) (*load.AvgStat, error)
+	virtualMemory func(ctx context.Context) (*mem.VirtualMemoryStat, error)
+	swapMemory    func(ctx context.Context) (*mem.SwapMemoryStat, error)
+	netCounters   func(ctx context.Context) ([]net.IOCountersStat, error) // Per interface
+	netInterfaces func(ctx context.Context) (net.InterfaceStatList, error)
+	diskCounters  func(ctx context.Context) (map[string]disk.IOCountersStat, error)
+	partitions    func(ctx context.Context) ([]disk.PartitionStat, error)
+	diskUsage     func(ctx context.Context, path string) (*disk.UsageStat, error)
+	hostInfo      func(ctx context.Context) (*host.InfoStat, error)
+	connections   func(ctx context.Context) ([]net.ConnectionStat, error) // IPv4 and IPv6
+	processes     func(ctx context.Context) ([]hostProcess, error)
+	process       func(ctx context.Context, pid int32) (hostProcess, error)
+
+	// Slow command output is cached with the platform that produced it.
+	// Each cache has its own lock, held only to read or store it and never
+	// while the command runs.
+	powerMu     sync.Mutex
+	powerOut    string
+	powerAt     time.Time
+	topologyMu  sync.Mutex
+	pCores      int
+	eCores      int
+	topologyAt  time.Time
+	diskTypesMu sync.Mutex
+	diskTypes   map[string]bool
+	diskTypesAt time.Time
+}
+
+// localPlatform is the machine mole runs on.
+var localPlatform = hostPlatform()
+
+func hostPlatform() *platform {
+	return &platform{
+		goos:   runtime.GOOS,
+		goarch: runtime.GOARCH,
+		run: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return exec.CommandContext(ctx, name, args...).Output()
+		},
+		lookPath:   exec.LookPath,
+		procRoot:   "/proc",
+		sysRoot:    "/sys",
+		cgroupRoot: "/sys/fs/cgroup",
+
+		cpuCounts:     cpu.CountsWithContext,
+		cpuTimes:      cpu.TimesWithContext,
+		loadAvg:       load.AvgWithContext,
+		virtualMemory: mem.VirtualMemoryWithContext,
+		swapMemory:    mem.SwapMemoryWithContext,
+		netCounters: func(ctx context.Context) ([]net.IOCountersStat, error) {
+			return net.IOCountersWithContext(ctx, true)
+		},
+		netInterfaces: net.InterfacesWithContext,
+		diskCounters: func(ctx context.Context) (map[string]disk.IOCountersStat, error) {
+			return disk.IOCountersWithContext(ctx)
+		},
+		partitions: func(ctx context.Context) ([]disk.PartitionStat, error) {
+			return disk.PartitionsWithContext(ctx, false)
+		},
+		diskUsage: disk.UsageWithContext,
+		hostInfo:  host.InfoWithContext,
+		connections: func(ctx context.Context) ([]net.ConnectionStat, error) {
+			return net.ConnectionsWithContext(ctx, "inet")
+		},
+		processes: func(ctx context.Context) ([]hostProcess, error) {
+			procs, err := process.ProcessesWithContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			out := make([]hostProcess, len(procs))
+			for i, p := range procs {
+				out[i] = gopsutilProcess{p}
+			}
+			return out, nil
+		},
+		process: func(ctx context.Context, pid int32) (hostProcess, error) {
+			p, err := process.NewProcessWithContext(ctx, pid)
+			if err != nil {
+				return nil, err
+			}
+			return gopsutilProcess{p}, nil
+		},
+	}
+}
+
+// hostProcess is what collectors read about one process.
+type hostProcess interface {
+	PID() int32
+	NameWithContext(ctx context.Context) (string, error)
+	PpidWithContext(ctx context.Context) (int32, error)
+	UidsWithContext(ctx context.Context) ([]uint32, error)
+	UsernameWithContext(ctx context.Context) (string, error)
+	NumThreadsWithContext(ctx context.Context) (int32, error)
+	NumFDsWithContext(ctx context.Context) (int32, error)
+	MemoryInfoWithContext(ctx context.Context) (*process.MemoryInfoStat, error)
+	TimesWithContext(ctx context.Context) (*cpu.TimesStat, error)
+	IOCountersWithContext(ctx context.Context) (*process.IOCountersStat, error)
+}
+
+// gopsutilProcess reads a host process through gopsutil.
+type gopsutilProcess struct{ *process.Process }
+
+func (p gopsutilProcess) PID() int32 { return p.Pid }
+
+type platformKey struct{}
+
+func withPlatform(ctx context.Context, p *platform) context.Context {
+	return context.WithValue(ctx, platformKey{}, p)
+}
+
+// platformFrom returns the platform collectors in ctx run against, the
+// host unless a test says otherwise.
+func platformFrom(ctx context.Context) *platform {
+	if p, ok := ctx.Value(platformKey{}).(*platform); ok && p != nil {
+		return p
+	}
+	return localPlatform
+}
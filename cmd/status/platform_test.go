@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/mem"
+	"github.com/shirou/gopsutil/v4/net"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// fixtureName is the file under a fixture directory holding the output of
+// name with args: the command line with spaces and slashes as underscores.
+func fixtureName(name string, args ...string) string {
+	line := strings.Join(append([]string{name}, args...), " ")
+	return strings.NewReplacer(" ", "_", "/", "_").Replace(line) + ".txt"
+}
+
+// fixturePlatform answers commands from output captured on a real machine
+// in dir. Commands without a file fail as if not installed; the filesystem
+// roots point inside dir. What gopsutil reports fails until a test sets it.
+func fixturePlatform(t *testing.T, goos, goarch, dir string) *platform {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	for _, e := range entries {
+		files = append(files, e.Name())
+	}
+	errNoCounters := errors.New("counters not in fixture")
+	return &platform{
+		goos:   goos,
+		goarch: goarch,
+		run: func(_ context.Context, name string, args ...string) ([]byte, error) {
+			out, err := os.ReadFile(filepath.Join(dir, fixtureName(name, args...)))
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("%s %s: not in fixture", name, strings.Join(args, " "))
+			}
+			return out, err
+		},
+		lookPath: func(name string) (string, error) {
+			prefix := strings.TrimSuffix(fixtureName(name), ".txt")
+			if slices.ContainsFunc(files, func(f string) bool {
+				return f == prefix+".txt" || strings.HasPrefix(f, prefix+"_")
+			}) {
+				return "/usr/bin/" + name, nil
+			}
+			return "", fmt.Errorf("%s: not in fixture", name)
+		},
+		procRoot:   filepath.Join(dir, "proc"),
+		sysRoot:    filepath.Join(dir, "sys"),
+		cgroupRoot: filepath.Join(dir, "sys", "fs", "cgroup"),
+
+		cpuCounts: func(context.Context, bool) (int, error) { return 0, errNoCounters },
+		cpuTimes: func(context.Context, bool) ([]cpu.TimesStat, error) {
+			return nil, errNoCounters
+		},
+		loadAvg: func(context.Context) (*load.AvgStat, error) { return nil, errNoCounters },
+		virtualMemory: func(context.Context) (*mem.VirtualMemoryStat, error) {
+			return nil, errNoCounters
+		},
+		swapMemory: func(context.Context) (*mem.SwapMemoryStat, error) { return nil, errNoCounters },
+		netCounters: func(context.Context) ([]net.IOCountersStat, error) {
+			return nil, errNoCounters
+		},
+		netInterfaces: func(context.Context) (net.InterfaceStatList, error) {
+			return nil, errNoCounters
+		},
+		diskCounters: func(context.Context) (map[string]disk.IOCountersStat, error) {
+			return nil, errNoCounters
+		},
+		partitions: func(context.Context) ([]disk.PartitionStat, error) { return nil, errNoCounters },
+		diskUsage: func(context.Context, string) (*disk.UsageStat, error) {
+			return nil, errNoCounters
+		},
+		hostInfo:    func(context.Context) (*host.InfoStat, error) { return nil, errNoCounters },
+		connections: func(context.Context) ([]net.ConnectionStat, error) { return nil, errNoCounters },
+		processes:   func(context.Context) ([]hostProcess, error) { return nil, errNoCounters },
+		process:     func(context.Context, int32) (hostProcess, error) { return nil, errNoCounters },
+	}
+}
+
+// fakeProcess is a canned hostProcess.
+type fakeProcess struct {
+	pid     int32
+	name    string
+	user    string
+	rss     uint64
+	cpuTime float64 // user+system seconds
+}
+
+func (p fakeProcess) PID() int32                                          { return p.pid }
+func (p fakeProcess) NameWithContext(context.Context) (string, error)     { return p.name, nil }
+func (p fakeProcess) PpidWithContext(context.Context) (int32, error)      { return 1, nil }
+func (p fakeProcess) UidsWithContext(context.Context) ([]uint32, error)   { return []uint32{501}, nil }
+func (p fakeProcess) UsernameWithContext(context.Context) (string, error) { return p.user, nil }
+func (p fakeProcess) NumThreadsWithContext(context.Context) (int32, error) {
+	return 4, nil
+}
+func (p fakeProcess) NumFDsWithContext(context.Context) (int32, error) { return 0, errNotImplemented }
+func (p fakeProcess) MemoryInfoWithContext(context.Context) (*process.MemoryInfoStat, error) {
+	return &process.MemoryInfoStat{RSS: p.rss}, nil
+}
+func (p fakeProcess) TimesWithContext(context.Context) (*cpu.TimesStat, error) {
+	return &cpu.TimesStat{User: p.cpuTime}, nil
+}
+func (p fakeProcess) IOCountersWithContext(context.Context) (*process.IOCountersStat, error) {
+	return nil, errNotImplemented
+}
+
+var errNotImplemented = errors.New("not implemented")
+
+// macFixture is command output captured on one Mac, under testdata/mac,
+// with what the collectors should make of it.
+type macFixture struct {
+	dir, goarch string
+	ram, disk   uint64
+
+	hardware   HardwareInfo
+	thermal    ThermalStatus
+	battery    BatteryStatus
+	pCores     int
+	eCores     int
+	freq       [2]int // Current and max MHz
+	fileBacked uint64
+	breakdown  []MemorySegment
+	gpus       []string
+	proxy      ProxyStatus
+	external   map[string]bool // Device -> external
+	load1      float64
+	netRx      map[int32]uint64
+	bluetooth  []BluetoothDevice
+}
+
+var macFixtures = []macFixture{
+	{
+		dir:    "apple-silicon",
+		goarch: "arm64",
+		ram:    18 << 30,
+		disk:   994662584320,
+		hardware: HardwareInfo{
+			Model: "MacBook Pro", CPUModel: "Apple M3 Pro", TotalRAM: "18.0 GiB",
+			DiskSize: "926.4 GiB", OSVersion: "macOS 14.6.1", RefreshRate: "60Hz",
+		},
+		thermal: ThermalStatus{CPUTemp: 30.12, BatteryPower: 6.851},
+		battery: BatteryStatus{
+			Percent: 72, Status: "discharging", TimeLeft: "6:41",
+			Health: "Normal", CycleCount: 143, Capacity: 96,
+		},
+		pCores:     5,
+		eCores:     6,
+		fileBacked: 262113 * 16384,
+		breakdown: []MemorySegment{
+			{"wired", 165339 * 16384}, {"active", 341877 * 16384},
+			{"inactive", 337204 * 16384}, {"compressed", 128763 * 16384},
+		},
+		gpus:     []string{"Apple M3 Pro"},
+		proxy:    ProxyStatus{Enabled: true, Type: "SOCKS", Host: "127.0.0.1:7890"},
+		external: map[string]bool{"/dev/disk3s1s1": false, "/dev/disk5s1": true},
+		load1:    3.41,
+		netRx:    map[int32]uint64{1873: 402318841, 412: 8843210},
+		// No system_profiler SPBluetoothDataType in the fixture.
+		bluetooth: []BluetoothDevice{{Name: "No Bluetooth info"}},
+	},
+	{
+		dir:    "intel",
+		goarch: "amd64",
+		ram:    16 << 30,
+		disk:   1000240963584,
+		// The mini display report has no refresh rate on this model.
+		hardware: HardwareInfo{
+			Model: "MacBook Pro", CPUModel: "8-Core Intel Core i9", TotalRAM: "16.0 GiB",
+			DiskSize: "931.5 GiB", OSVersion: "macOS 12.7.6",
+		},
+		// No PowerTelemetryData before Apple Silicon.
+		thermal: ThermalStatus{CPUTemp: 31.05, AdapterPower: 96},
+		// No Maximum Capacity line on Intel.
+		battery: BatteryStatus{
+			Percent: 100, Status: "charged", TimeLeft: "0:00",
+			Health: "Normal", CycleCount: 612,
+		},
+		freq:       [2]int{2300, 2300},
+		fileBacked: 1024512 * 4096,
+		breakdown: []MemorySegment{
+			{"wired", 612330 * 4096}, {"active", 1296322 * 4096},
+			{"inactive", 1260117 * 4096}, {"compressed", 514021 * 4096},
+		},
+		gpus:     []string{"Intel UHD Graphics 630", "AMD Radeon Pro 5500M"},
+		proxy:    ProxyStatus{Enabled: true, Type: "PAC", Host: "wpad.corp.example.com"},
+		external: map[string]bool{"/dev/disk1s1": false},
+		load1:    2.18,
+		netRx:    map[int32]uint64{1544: 912330482},
+		bluetooth: []BluetoothDevice{
+			{Name: "Magic Keyboard", Connected: true, Battery: "76%"},
+			{Name: "WH-1000XM4"},
+		},
+	},
+}
+
+func TestMacFixtures(t *testing.T) {
+	for _, key := range []string{"https_proxy", "HTTPS_PROXY", "http_proxy", "HTTP_PROXY", "all_proxy", "ALL_PROXY"} {
+		t.Setenv(key, "")
+	}
+	for _, fx := range macFixtures {
+		t.Run(fx.dir, func(t *testing.T) {
+			p := fixturePlatform(t, "darwin", fx.goarch, filepath.Join("testdata", "mac", fx.dir))
+			ctx := withPlatform(context.Background(), p)
+
+			var disks []DiskStatus
+			for _, dev := range slices.Sorted(maps.Keys(fx.external)) {
+				disks = append(disks, DiskStatus{Device: dev, Mount: "/"})
+			}
+			disks[0].Total = fx.disk
+			if got := collectHardware(ctx, fx.ram, disks); got != fx.hardware {
+				t.Errorf("hardware = %+v, want %+v", got, fx.hardware)
+			}
+
+			if got := collectThermal(ctx, nil); got != fx.thermal {
+				t.Errorf("thermal = %+v, want %+v", got, fx.thermal)
+			}
+			batts, err := collectBatteries(ctx)
+			if err != nil || len(batts) != 1 || batts[0] != fx.battery {
+				t.Errorf("batteries = %+v, %v; want %+v", batts, err, fx.battery)
+			}
+			if pc, ec := getCoreTopology(ctx); pc != fx.pCores || ec != fx.eCores {
+				t.Errorf("topology = %dP+%dE, want %dP+%dE", pc, ec, fx.pCores, fx.eCores)
+			}
+			if cur, maxFreq := readCPUFreq(ctx); [2]int{cur, maxFreq} != fx.freq {
+				t.Errorf("clock = %d/%d MHz, want %v", cur, maxFreq, fx.freq)
+			}
+			stat, _ := readVMStat(ctx)
+			if got := stat.bytes("File-backed pages"); got != fx.fileBacked {
+				t.Errorf("file-backed memory = %d, want %d", got, fx.fileBacked)
+			}
+			if got := stat.breakdown(); !slices.Equal(got, fx.breakdown) {
+				t.Errorf("breakdown = %+v, want %+v", got, fx.breakdown)
+			}
+
+			gpus, err := readMacGPUInfo(ctx)
+			var names []string
+			for _, g := range gpus {
+				names = append(names, g.Name)
+			}
+			if err != nil || !slices.Equal(names, fx.gpus) {
+				t.Errorf("gpus = %v, %v; want %v", names, err, fx.gpus)
+			}
+
+			if got := collectProxy(ctx); got != fx.proxy {
+				t.Errorf("proxy = %+v, want %+v", got, fx.proxy)
+			}
+			annotateDiskTypes(ctx, disks)
+			for _, d := range disks {
+				if d.External != fx.external[d.Device] {
+					t.Errorf("%s external = %v", d.Device, d.External)
+				}
+			}
+			if avg, err := fallbackLoadAvgFromUptime(ctx); err != nil || avg.Load1 != fx.load1 {
+				t.Errorf("uptime load = %+v, %v; want %v", avg, err, fx.load1)
+			}
+			counters := readNettopCounters(ctx)
+			for pid, rx := range fx.netRx {
+				if counters[pid].rx != rx {
+					t.Errorf("nettop pid %d rx = %d, want %d", pid, counters[pid].rx, rx)
+				}
+			}
+			if got := collectBluetooth(ctx); !slices.Equal(got, fx.bluetooth) {
+				t.Errorf("bluetooth = %+v, want %+v", got, fx.bluetooth)
+			}
+
+			// gopsutil's counters are merged with the Mac's own tools.
+			p.virtualMemory = func(context.Context) (*mem.VirtualMemoryStat, error) {
+				return &mem.VirtualMemoryStat{Total: fx.ram, Used: fx.ram / 2, UsedPercent: 50}, nil
+			}
+			memory, err := NewCollector().collectMemory(ctx, time.Now())
+			if err != nil || memory.Total != fx.ram || memory.Cached != fx.fileBacked || !slices.Equal(memory.Breakdown, fx.breakdown) {
+				t.Errorf("memory = %+v, %v", memory, err)
+			}
+			cores := max(fx.pCores+fx.eCores, 4)
+			p.cpuTimes = busyCPUTimes(cores, 50)
+			cpuStatus, err := NewCollector().collectCPU(ctx)
+			if err != nil || cpuStatus.Usage != 50 || len(cpuStatus.PerCore) != cores || cpuStatus.Load1 != fx.load1 {
+				t.Errorf("cpu = %+v, %v; want 50%% over %d cores, load %v", cpuStatus, err, cores, fx.load1)
+			}
+		})
+	}
+}
+
+func TestCollectProcessesFromPlatform(t *testing.T) {
+	p := fixturePlatform(t, "darwin", "arm64", filepath.Join("testdata", "mac", "apple-silicon"))
+	ctx := withPlatform(context.Background(), p)
+	p.virtualMemory = func(context.Context) (*mem.VirtualMemoryStat, error) {
+		return &mem.VirtualMemoryStat{Total: 16 << 30}, nil
+	}
+	cpuTime := 10.0
+	p.processes = func(context.Context) ([]hostProcess, error) {
+		return []hostProcess{
+			fakeProcess{pid: 1873, name: "Safari", user: "tw93", rss: 4 << 30, cpuTime: cpuTime},
+			fakeProcess{pid: 99, name: ""}, // Exited while listed.
+		}, nil
+	}
+
+	c := NewCollector()
+	start := time.Now()
+	if _, err := c.collectProcesses(ctx, start); err != nil {
+		t.Fatal(err)
+	}
+	cpuTime = 10.5
+	procs, err := c.collectProcesses(ctx, start.Add(time.Second))
+	if err != nil || len(procs) != 1 {
+		t.Fatalf("processes = %+v, %v", procs, err)
+	}
+	got := procs[0]
+	if got.PID != 1873 || got.User != "tw93" || got.Memory != 25 || got.CPU != 50 || got.Threads != 4 {
+		t.Errorf("process = %+v", got)
+	}
+}
+
+// A slow system_profiler run doesn't hold up collectors using other caches.
+func TestPlatformCachesDoNotBlockEachOther(t *testing.T) {
+	p := fixturePlatform(t, "darwin", "arm64", filepath.Join("testdata", "mac", "apple-silicon"))
+	fixtureRun := p.run
+	release := make(chan struct{})
+	p.run = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		if name == "system_profiler" {
+			<-release
+		}
+		return fixtureRun(ctx, name, args...)
+	}
+	ctx := withPlatform(context.Background(), p)
+	done := make(chan string)
+	go func() { done <- getSystemPowerOutput(ctx) }()
+	defer func() {
+		close(release)
+		<-done
+	}()
+
+	topology := make(chan int)
+	go func() {
+		pc, _ := getCoreTopology(ctx)
+		topology <- pc
+	}()
+	select {
+	case pc := <-topology:
+		if pc != 5 {
+			t.Errorf("P cores = %d, want 5", pc)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("core topology waited for system_profiler")
+	}
+}
+
+// A Collector's platform reaches every collector through runSource.
+func TestCollectorPlatform(t *testing.T) {
+	src := &funcSource[HardwareInfo]{
+		name:    "hardware",
+		timeout: 5 * time.Second,
+		collect: func(ctx context.Context, _ time.Time) (HardwareInfo, error) {
+			return collectHardware(ctx, 16<<30, nil), nil
+		},
+		apply: func(s *MetricsSnapshot, v HardwareInfo) { s.Hardware = v },
+	}
+	c := newTestCollector(src)
+	c.platform = fixturePlatform(t, "darwin", "amd64", filepath.Join("testdata", "mac", "intel"))
+	snap, _ := c.Collect()
+	if snap.Hardware.CPUModel != "8-Core Intel Core i9" {
+		t.Errorf("hardware = %+v", snap.Hardware)
+	}
+
+	// Linux collectors read the platform's roots, not the host's.
+	c.platform = &platform{goos: "linux", goarch: "riscv64", sysRoot: t.TempDir()}
+	c.sourceState = make(map[string]*sourceState)
+	snap, _ = c.Collect()
+	if snap.Hardware.OSVersion != "linux" || snap.Hardware.CPUModel != "riscv64" {
+		t.Errorf("hardware = %+v", snap.Hardware)
+	}
+	ctx := withPlatform(context.Background(), c.platform)
+	if batts, err := collectBatteries(ctx); err == nil {
+		t.Errorf("empty sysfs reported batteries: %+v", batts)
+	}
+}
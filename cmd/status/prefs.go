@@ -0,0 +1,268 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tw93/mole/internal/theme"
+	"github.com/tw93/mole/internal/units"
+)
+
+// prefsVersion is bumped when a field changes meaning; older files are
+// upgraded on load.
+const prefsVersion = 1
+
+const (
+	minRefreshInterval = 250 * time.Millisecond
+	maxRefreshInterval = 10 * time.Second
+)
+
+var (
+	errUnknownPref  = errors.New("unknown preference")
+	errPrefsVersion = errors.New("preferences file is from a newer mole")
+)
+
+// statusPrefs is the mo status preferences file, ~/.config/mole/status.json.
+type statusPrefs struct {
+	Version   int          `json:"version"`
+	Interval  prefDuration `json:"refresh_interval"`
+	Units     unitPrefs    `json:"units"`
+	Layout    layoutPrefs  `json:"layout"`
+	Theme     string       `json:"theme"`
+	Animation bool         `json:"animation"` // Animate the header cat
+	CatHidden bool         `json:"cat_hidden"`
+}
+
+type unitPrefs struct {
+	Bytes       string `json:"bytes"`       // iec (KiB steps of 1024) or si (kB steps of 1000)
+	Network     string `json:"network"`     // bytes or bits per second
+	Temperature string `json:"temperature"` // celsius or fahrenheit
+}
+
+type layoutPrefs struct {
+	Cards   []string `json:"cards,omitempty"`   // Card names in display order; empty shows all
+	Columns int      `json:"columns,omitempty"` // 0 picks by terminal width
+}
+
+// prefDuration is a time.Duration written as "1s" or "500ms".
+type prefDuration time.Duration
+
+func (d prefDuration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *prefDuration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = prefDuration(v)
+	return nil
+}
+
+func defaultPrefs() statusPrefs {
+	return statusPrefs{
+		Version:   prefsVersion,
+		Interval:  prefDuration(refreshInterval),
+		Units:     unitPrefs{Bytes: "iec", Network: "bytes", Temperature: "celsius"},
+		Theme:     "auto",
+		Animation: true,
+	}
+}
+
+// validate reports the first out-of-range or unknown value.
+func (p statusPrefs) validate() error {
+	if d := time.Duration(p.Interval); d < minRefreshInterval || d > maxRefreshInterval {
+		return fmt.Errorf("refresh_interval %s: must be between %s and %s", d, minRefreshInterval, maxRefreshInterval)
+	}
+	for _, c := range []struct {
+		key, value string
+		allowed    []string
+	}{
+		{"theme", p.Theme, theme.Names},
+		{"units.bytes", p.Units.Bytes, units.ByteNames},
+		{"units.network", p.Units.Network, units.RateNames},
+		{"units.temperature", p.Units.Temperature, units.TempNames},
+	} {
+		if !slices.Contains(c.allowed, c.value) {
+			return fmt.Errorf("%s %q: must be one of %s", c.key, c.value, strings.Join(c.allowed, ", "))
+		}
+	}
+	if p.Layout.Columns < 0 || p.Layout.Columns > maxColumns {
+		return fmt.Errorf("layout.columns %d: must be between 0 (auto) and %d", p.Layout.Columns, maxColumns)
+	}
+	names := registeredSourceNames()
+	for _, card := range p.Layout.Cards {
+		if !slices.Contains(names, card) {
+			return fmt.Errorf("layout.cards %q: must be among %s", card, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// cardLayout converts the layout preferences for the dashboard.
+func (p statusPrefs) cardLayout() cardLayout {
+	return newCardLayout(p.Layout.Cards, p.Layout.Columns)
+}
+
+// formatter builds the unit formatter, letting MO_UNITS override the
+// file for one run.
+func (p statusPrefs) formatter() (units.Formatter, error) {
+	f := units.Default()
+	for _, v := range []string{p.Units.Bytes, p.Units.Network, p.Units.Temperature} {
+		// Values were checked by validate.
+		_ = f.Set(v)
+	}
+	return f.FromEnv()
+}
+
+// prefsPath returns the path to the status preferences file.
+func prefsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "mole", "status.json")
+}
+
+// legacyPrefsPath is the key=value file used before status.json.
+func legacyPrefsPath(path string) string {
+	return filepath.Join(filepath.Dir(path), "status_prefs")
+}
+
+// loadPrefs reads path, falling back to the legacy file and then defaults.
+// Invalid values are replaced by their defaults so a bad edit never stops
+// the dashboard from starting.
+func loadPrefs(path string) (statusPrefs, error) {
+	p := defaultPrefs()
+	if path == "" {
+		return p, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return readLegacyPrefs(legacyPrefsPath(path), p), nil
+	}
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return defaultPrefs(), fmt.Errorf("%s: %w", path, err)
+	}
+	if p.Version > prefsVersion {
+		err = fmt.Errorf("%s: %w (version %d)", path, errPrefsVersion, p.Version)
+	}
+	p.Version = prefsVersion
+	if verr := p.validate(); verr != nil {
+		return sanitizePrefs(p), fmt.Errorf("%s: %w", path, verr)
+	}
+	return p, err
+}
+
+// sanitizePrefs resets each invalid field to its default.
+func sanitizePrefs(p statusPrefs) statusPrefs {
+	def := defaultPrefs()
+	if d := time.Duration(p.Interval); d < minRefreshInterval || d > maxRefreshInterval {
+		p.Interval = def.Interval
+	}
+	if !slices.Contains(theme.Names, p.Theme) {
+		p.Theme = def.Theme
+	}
+	if !slices.Contains(units.ByteNames, p.Units.Bytes) {
+		p.Units.Bytes = def.Units.Bytes
+	}
+	if !slices.Contains(units.RateNames, p.Units.Network) {
+		p.Units.Network = def.Units.Network
+	}
+	if !slices.Contains(units.TempNames, p.Units.Temperature) {
+		p.Units.Temperature = def.Units.Temperature
+	}
+	if p.Layout.Columns < 0 || p.Layout.Columns > maxColumns {
+		p.Layout.Columns = def.Layout.Columns
+	}
+	names := registeredSourceNames()
+	p.Layout.Cards = slices.DeleteFunc(slices.Clone(p.Layout.Cards), func(card string) bool {
+		return !slices.Contains(names, card)
+	})
+	return p
+}
+
+// readLegacyPrefs applies the old key=value file (cat_hidden, cards,
+// columns) over p.
+func readLegacyPrefs(path string, p statusPrefs) statusPrefs {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return p
+	}
+	for line := range strings.Lines(string(data)) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "cat_hidden":
+			p.CatHidden = value == "true"
+		case "cards":
+			p.Layout.Cards = splitList(value)
+		case "columns":
+			var n int
+			if _, err := fmt.Sscan(value, &n); err == nil && n >= 0 {
+				p.Layout.Columns = min(n, maxColumns)
+			}
+		}
+	}
+	return p
+}
+
+// savePrefs writes p to path, replacing the file atomically. A file that
+// did not load cleanly, being corrupt, invalid or from a newer mole, is
+// first kept as path.bak so its settings are not silently lost.
+func savePrefs(path string, p statusPrefs) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := loadPrefs(path); err != nil {
+		if err := backupPrefs(path); err != nil {
+			return err
+		}
+	}
+	p.Version = prefsVersion
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Ensure directory exists
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// backupPrefs copies path to path.bak.
+func backupPrefs(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("backing up %s: %w", path, err)
+	}
+	return os.WriteFile(path+".bak", data, 0644)
+}
+
+func splitList(raw string) []string {
+	var out []string
+	for item := range strings.SplitSeq(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPrefsPath(t *testing.T) string {
+	t.Helper()
+	return filepath.Join(t.TempDir(), "mole", "status.json")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPrefsDefaults(t *testing.T) {
+	p, err := loadPrefs(testPrefsPath(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Duration(p.Interval) != refreshInterval || p.Theme != "auto" || !p.Animation || p.CatHidden || p.validate() != nil {
+		t.Errorf("defaults = %+v", p)
+	}
+}
+
+func TestLoadPrefsMigratesLegacyFile(t *testing.T) {
+	path := testPrefsPath(t)
+	// The original file held a single literal line.
+	writeFile(t, legacyPrefsPath(path), "cat_hidden=true\n")
+	p, err := loadPrefs(path)
+	if err != nil || !p.CatHidden || p.Theme != "auto" {
+		t.Fatalf("legacy cat_hidden: %+v, %v", p, err)
+	}
+
+	writeFile(t, legacyPrefsPath(path), "cards=cpu, network\ncolumns=3\ncat_hidden=false\n")
+	p, _ = loadPrefs(path)
+	if p.CatHidden || strings.Join(p.Layout.Cards, ",") != "cpu,network" || p.Layout.Columns != 3 {
+		t.Errorf("legacy layout: %+v", p)
+	}
+
+	// Saving writes the new file; it then takes precedence.
+	if err := savePrefs(path, p); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, legacyPrefsPath(path), "cat_hidden=true\n")
+	if p, _ = loadPrefs(path); p.CatHidden {
+		t.Error("legacy file should be ignored once status.json exists")
+	}
+}
+
+func TestPrefsRoundTrip(t *testing.T) {
+	path := testPrefsPath(t)
+	want := defaultPrefs()
+	want.Interval = prefDuration(500 * time.Millisecond)
+	want.Units.Network = "bits"
+	want.Layout = layoutPrefs{Cards: []string{"cpu", "probes"}, Columns: 2}
+	want.Animation = false
+	if err := savePrefs(path, want); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	if !strings.Contains(string(raw), `"refresh_interval": "500ms"`) || !strings.Contains(string(raw), `"version": 1`) {
+		t.Errorf("file:\n%s", raw)
+	}
+	got, err := loadPrefs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Interval != want.Interval || got.Units != want.Units || got.Animation || got.Layout.Columns != 2 ||
+		strings.Join(got.Layout.Cards, ",") != "cpu,probes" {
+		t.Errorf("round trip = %+v", got)
+	}
+}
+
+func TestLoadPrefsInvalidValues(t *testing.T) {
+	path := testPrefsPath(t)
+	writeFile(t, path, `{"version": 1, "refresh_interval": "1ms", "theme": "neon", "units": {"bytes": "si"}, "animation": false}`)
+
+	p, err := loadPrefs(path)
+	if err == nil || !strings.Contains(err.Error(), "refresh_interval") {
+		t.Errorf("err = %v", err)
+	}
+	// Bad values fall back; good ones survive.
+	if time.Duration(p.Interval) != refreshInterval || p.Theme != "auto" || p.Units.Bytes != "si" ||
+		p.Units.Network != "bytes" || p.Animation {
+		t.Errorf("sanitized = %+v", p)
+	}
+
+	writeFile(t, path, `{"version": 7, "theme": "dark"}`)
+	if p, err = loadPrefs(path); !errors.Is(err, errPrefsVersion) || p.Theme != "dark" {
+		t.Errorf("newer version: %+v, %v", p, err)
+	}
+
+	writeFile(t, path, `{not json`)
+	if p, err = loadPrefs(path); err == nil || p.Theme != "auto" {
+		t.Errorf("corrupt file: %+v, %v", p, err)
+	}
+}
+
+// Saving over a file that did not load cleanly keeps the original.
+func TestSavePrefsBacksUpUnreadableFile(t *testing.T) {
+	for _, original := range []string{`{not json`, `{"version": 7, "theme": "dark", "sparkles": true}`} {
+		path := testPrefsPath(t)
+		writeFile(t, path, original)
+		p, _ := loadPrefs(path)
+		p.CatHidden = true
+		if err := savePrefs(path, p); err != nil {
+			t.Fatal(err)
+		}
+		if data, err := os.ReadFile(path + ".bak"); err != nil || string(data) != original {
+			t.Errorf("backup = %q, %v; want %q", data, err, original)
+		}
+		if p, err := loadPrefs(path); err != nil || !p.CatHidden {
+			t.Errorf("saved prefs = %+v, %v", p, err)
+		}
+	}
+
+	// A clean file needs no backup.
+	path := testPrefsPath(t)
+	if err := savePrefs(path, defaultPrefs()); err != nil {
+		t.Fatal(err)
+	}
+	if err := savePrefs(path, defaultPrefs()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".bak"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("clean save left a backup: %v", err)
+	}
+}
+
+func TestLayoutCardsMustBeSources(t *testing.T) {
+	path := testPrefsPath(t)
+	writeFile(t, path, `{"version": 1, "layout": {"cards": ["cpu", "weather", "memory"]}}`)
+	p, err := loadPrefs(path)
+	if err == nil || !strings.Contains(err.Error(), "weather") {
+		t.Errorf("err = %v", err)
+	}
+	if !slices.Equal(p.Layout.Cards, []string{"cpu", "memory"}) {
+		t.Errorf("cards = %v", p.Layout.Cards)
+	}
+	if err := runConfig([]string{"set", "layout.cards", "cpu,wether"}, path, io.Discard); err == nil {
+		t.Error("unknown card accepted")
+	}
+}
+
+func TestRunConfig(t *testing.T) {
+	path := testPrefsPath(t)
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		err := runConfig(args, path, &out)
+		return out.String(), err
+	}
+
+	if out, err := run("set", "refresh_interval", "2s"); err != nil || out != "refresh_interval = 2s\n" {
+		t.Fatalf("set = %q, %v", out, err)
+	}
+	if _, err := run("set", "layout.cards", "cpu,memory"); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := run("get", "layout.cards"); out != "cpu,memory\n" {
+		t.Errorf("get layout.cards = %q", out)
+	}
+	if out, _ := run(); !strings.Contains(out, "# "+path) || !strings.Contains(out, `"refresh_interval": "2s"`) {
+		t.Errorf("show:\n%s", out)
+	}
+	if out, _ := run("path"); out != path+"\n" {
+		t.Errorf("path = %q", out)
+	}
+
+	for _, bad := range [][]string{
+		{"set", "theme", "neon"},
+		{"set", "refresh_interval", "1m"},
+		{"set", "animation", "sometimes"},
+		{"set", "colour", "red"},
+		{"get"},
+		{"frobnicate"},
+	} {
+		if _, err := run(bad...); err == nil {
+			t.Errorf("%v should fail", bad)
+		}
+	}
+	// Failed sets leave the file alone.
+	if p, _ := loadPrefs(path); p.Theme != "auto" || time.Duration(p.Interval) != 2*time.Second {
+		t.Errorf("prefs after failed sets = %+v", p)
+	}
+
+	if _, err := run("reset"); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := loadPrefs(path); len(p.Layout.Cards) != 0 || time.Duration(p.Interval) != refreshInterval {
+		t.Errorf("after reset = %+v", p)
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+)
+
+// recordVersion is bumped when the --record file layout changes.
+const recordVersion = 1
+
+// recordFrame is one line of a --record file: a snapshot and the raw
+// command output its parsers read since the previous frame.
+type recordFrame struct {
+	Version  int
+	Snapshot MetricsSnapshot
+	Commands []recordedCommand `json:",omitempty"`
+}
+
+// recordedCommand is one runCmd call.
+type recordedCommand struct {
+	Source string `json:",omitempty"` // Source whose collection ran it
+	Name   string
+	Args   []string
+	Output string
+	Error  string `json:",omitempty"`
+}
+
+// tape replays the frame's command output through runCmd.
+func (f recordFrame) tape() *commandTape {
+	return &commandTape{replay: true, commands: slices.Clone(f.Commands)}
+}
+
+type commandTapeKey struct{}
+
+// commandTape captures runCmd output while recording, or serves it back in
+// place of running the command while replaying.
+type commandTape struct {
+	mu       sync.Mutex
+	replay   bool
+	commands []recordedCommand
+	frame    int // Frames drained so far
+}
+
+type tapeSourceKey struct{}
+
+// tapeSource is the source a recorded command ran for and the frame its
+// collection started in.
+type tapeSource struct {
+	name  string
+	frame int
+}
+
+// withTapeSource records the commands run under ctx for source, in the
+// tape's current frame.
+func withTapeSource(ctx context.Context, tape *commandTape, source string) context.Context {
+	tape.mu.Lock()
+	frame := tape.frame
+	tape.mu.Unlock()
+	return context.WithValue(withCommandTape(ctx, tape), tapeSourceKey{}, tapeSource{name: source, frame: frame})
+}
+
+func withCommandTape(ctx context.Context, tape *commandTape) context.Context {
+	return context.WithValue(ctx, commandTapeKey{}, tape)
+}
+
+func tapeFrom(ctx context.Context) *commandTape {
+	tape, _ := ctx.Value(commandTapeKey{}).(*commandTape)
+	return tape
+}
+
+// add keeps cmd for the current frame, tagged with its source. A
+// collection that timed out and finished after its frame was written would
+// file its output under a later frame, so it is dropped.
+func (t *commandTape) add(ctx context.Context, cmd recordedCommand) {
+	src, tagged := ctx.Value(tapeSourceKey{}).(tapeSource)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tagged {
+		if src.frame != t.frame {
+			return
+		}
+		cmd.Source = src.name
+	}
+	t.commands = append(t.commands, cmd)
+}
+
+// drain returns and forgets what was captured so far, closing the frame.
+func (t *commandTape) drain() []recordedCommand {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cmds := t.commands
+	t.commands = nil
+	t.frame++
+	return cmds
+}
+
+// play returns the recorded result of name with args. Each recording is
+// used once, in order, except the last, which answers every later call.
+func (t *commandTape) play(name string, args []string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	matches := 0
+	var last int
+	for i, c := range t.commands {
+		if c.Name == name && slices.Equal(c.Args, args) {
+			if matches++; matches == 1 {
+				last = i
+			}
+		}
+	}
+	if matches == 0 {
+		return "", fmt.Errorf("%s: not in recording", name)
+	}
+	rec := t.commands[last]
+	if matches > 1 {
+		t.commands = slices.Delete(t.commands, last, last+1)
+	}
+	if rec.Error != "" {
+		return rec.Output, errors.New(rec.Error)
+	}
+	return rec.Output, nil
+}
+
+// sessionRecorder appends a frame to the --record file per collection.
+type sessionRecorder struct {
+	file *os.File
+	enc  *json.Encoder
+	tape *commandTape
+}
+
+func newSessionRecorder(path string) (*sessionRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionRecorder{file: f, enc: json.NewEncoder(f), tape: &commandTape{}}, nil
+}
+
+func (r *sessionRecorder) write(snap MetricsSnapshot) error {
+	return r.enc.Encode(recordFrame{Version: recordVersion, Snapshot: snap, Commands: r.tape.drain()})
+}
+
+func (r *sessionRecorder) Close() error {
+	return r.file.Close()
+}
+
+// sessionReplay serves the snapshots of a --record file in order, holding
+// the last one once the recording runs out.
+type sessionReplay struct {
+	mu     sync.Mutex
+	frames []recordFrame
+	next   int
+}
+
+func loadReplay(path string) (*sessionReplay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readReplay(f)
+}
+
+func readReplay(r io.Reader) (*sessionReplay, error) {
+	dec := json.NewDecoder(r)
+	var frames []recordFrame
+	for {
+		var frame recordFrame
+		err := dec.Decode(&frame)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("frame %d: %w", len(frames)+1, err)
+		}
+		if frame.Version > recordVersion {
+			return nil, fmt.Errorf("frame %d: recording version %d is newer than this mole (%d)", len(frames)+1, frame.Version, recordVersion)
+		}
+		frames = append(frames, frame)
+	}
+	if len(frames) == 0 {
+		return nil, errors.New("recording has no snapshots")
+	}
+	return &sessionReplay{frames: frames}, nil
+}
+
+// snapshot returns the next recorded snapshot, with the health score
+// computed again so scoring changes can be checked against old recordings.
+func (r *sessionReplay) snapshot() MetricsSnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snap := r.frames[min(r.next, len(r.frames)-1)].Snapshot
+	if r.next < len(r.frames) {
+		r.next++
+	}
+	scoreSnapshot(&snap)
+	return snap
+}
+
+// position reports how many frames have been shown, out of the total.
+func (r *sessionReplay) position() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.next, len(r.frames)
+}
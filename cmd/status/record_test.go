@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	rec, err := newSessionRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := &funcSource[string]{
+		name:    "echo",
+		timeout: 5 * time.Second,
+		collect: func(ctx context.Context, _ time.Time) (string, error) {
+			return runCmd(ctx, "echo", "vm_stat says hi")
+		},
+		apply: func(s *MetricsSnapshot, v string) { s.Uptime = strings.TrimSpace(v) },
+	}
+	c := newTestCollector(src)
+	c.record = rec
+	for range 2 {
+		if _, err := c.Collect(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rp, err := loadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rp.frames) != 2 {
+		t.Fatalf("frames = %d, want 2", len(rp.frames))
+	}
+	cmds := rp.frames[0].Commands
+	if len(cmds) != 1 || cmds[0].Source != "echo" || cmds[0].Name != "echo" || cmds[0].Output != "vm_stat says hi\n" {
+		t.Errorf("recorded commands = %+v", cmds)
+	}
+
+	// The recorded output answers runCmd without running anything.
+	ctx := withCommandTape(context.Background(), rp.frames[1].tape())
+	if out, err := runCmd(ctx, "echo", "vm_stat says hi"); err != nil || out != "vm_stat says hi\n" {
+		t.Errorf("replayed runCmd = %q, %v", out, err)
+	}
+	if _, err := runCmd(ctx, "echo", "something else"); err == nil {
+		t.Error("commands missing from the recording should fail")
+	}
+
+	replayer := &Collector{replay: rp}
+	for i := range 3 {
+		snap, err := replayer.Collect()
+		if err != nil || snap.Uptime != "vm_stat says hi" {
+			t.Errorf("replay %d = %q, %v", i, snap.Uptime, err)
+		}
+	}
+	if shown, total := rp.position(); shown != 2 || total != 2 {
+		t.Errorf("position = %d/%d; the last frame should be held", shown, total)
+	}
+}
+
+func TestTapeDropsLateCommands(t *testing.T) {
+	tape := &commandTape{}
+	slow := withTapeSource(context.Background(), tape, "gpu")
+	tape.drain() // The frame is written while gpu is still running.
+	fast := withTapeSource(context.Background(), tape, "battery")
+
+	tape.add(slow, recordedCommand{Name: "system_profiler"})
+	tape.add(fast, recordedCommand{Name: "pmset"})
+	cmds := tape.drain()
+	if len(cmds) != 1 || cmds[0].Name != "pmset" || cmds[0].Source != "battery" {
+		t.Errorf("next frame's commands = %+v; the late gpu output should be dropped", cmds)
+	}
+}
+
+func TestReadReplayErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"empty":   "",
+		"corrupt": `{"Version":1,"Snapshot":{`,
+		"newer":   `{"Version":99,"Snapshot":{}}`,
+	} {
+		if _, err := readReplay(strings.NewReader(data)); err == nil {
+			t.Errorf("%s recording should fail", name)
+		}
+	}
+}
+
+// The fixture is a synthetic recording in the --record layout, written by
+// hand with macOS command output; it drives the macOS parsers on any
+// platform.
+func TestReplayMacFixture(t *testing.T) {
+	rp, err := loadReplay(filepath.Join("testdata", "record", "macbook.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, frame := range rp.frames {
+		ctx := withCommandTape(context.Background(), frame.tape())
+		want := frame.Snapshot
+
+		stat, _ := readVMStat(ctx)
+		if got := stat.bytes("File-backed pages"); got != want.Memory.Cached {
+			t.Errorf("frame %d: file-backed memory = %d, want %d", i, got, want.Memory.Cached)
+		}
+		out, err := runCmd(ctx, "pmset", "-g", "batt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		batts := parsePMSet(out, "Normal", 123, 91)
+		if len(batts) != 1 || batts[0] != want.Batteries[0] {
+			t.Errorf("frame %d: batteries = %+v, want %+v", i, batts, want.Batteries)
+		}
+		if _, err := runCmd(ctx, "memory_pressure"); err == nil || err.Error() != "exit status 1" {
+			t.Errorf("frame %d: recorded failure not replayed: %v", i, err)
+		}
+	}
+}
+
+func TestReplayDrivesDashboard(t *testing.T) {
+	rp, err := loadReplay(filepath.Join("testdata", "record", "macbook.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := model{collector: NewCollector(), width: 120, height: 50, interval: time.Second}
+	m.collector.replay = rp
+	step := func() {
+		t.Helper()
+		next, _ := m.Update(metricsMsg{data: m.collector.replay.snapshot()})
+		m = next.(model)
+	}
+
+	step()
+	view := stripANSI(m.View())
+	for _, want := range []string{"▶ replay 1/2", "Apple M1 Pro", "87.0%", "Discharging · 5:12"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("frame 1 view missing %q:\n%s", want, view)
+		}
+	}
+	healthy := m.metrics.HealthScore
+	if healthy == 0 {
+		t.Error("health score should be computed from the recording")
+	}
+
+	step()
+	if m.metrics.HealthScore >= healthy {
+		t.Errorf("busy frame scored %d, quiet frame %d", m.metrics.HealthScore, healthy)
+	}
+	if !strings.Contains(stripANSI(m.View()), "▶ replay 2/2") {
+		t.Error("header should track the replay position")
+	}
+
+	// Recorded PIDs belong to another machine.
+	m.procPanel.open = true
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if m = next.(model); !strings.Contains(m.procPanel.message, "replaying") || m.procPanel.confirm != nil {
+		t.Errorf("signals should be refused during replay: %q", m.procPanel.message)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// agentProtocolVersion is bumped on incompatible changes to agentFrame.
+	agentProtocolVersion = 1
+	remoteRetryDelay     = 5 * time.Second
+	defaultRemoteCommand = "mo status --agent"
+)
+
+// agentFrame is one JSON line written by `mo status --agent`.
+type agentFrame struct {
+	Version  int             `json:"v"`
+	Snapshot MetricsSnapshot `json:"snapshot"`
+}
+
+// runAgent streams a snapshot per interval to w until ctx is done or the
+// reader goes away.
+func runAgent(ctx context.Context, c *Collector, w io.Writer, interval time.Duration) error {
+	enc := json.NewEncoder(w)
+	for {
+		// Errors are carried per source in the snapshot.
+		data, _ := c.Collect()
+		if err := enc.Encode(agentFrame{Version: agentProtocolVersion, Snapshot: data}); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// commandTransport runs an agent as a subprocess and reads snapshots from its
+// stdout. Remote hosts go through ssh; tests run a local subprocess.
+type commandTransport struct {
+	name string
+	argv []string
+	env  []string
+}
+
+func sshTransport(host, remoteCommand string) commandTransport {
+	// "--" keeps a host such as "-oProxyCommand=..." from being read as an option.
+	argv := []string{"ssh", "-T", "-o", "BatchMode=yes", "-o", "ServerAliveInterval=5", "--", host}
+	argv = append(argv, strings.Fields(remoteCommand)...)
+	return commandTransport{name: host, argv: argv}
+}
+
+// remoteMsg carries a snapshot or a connection error for one remote host.
+type remoteMsg struct {
+	host int
+	data MetricsSnapshot
+	err  error
+}
+
+// stream runs the agent once, forwarding every frame until it exits.
+func (t commandTransport) stream(ctx context.Context, host int, out chan<- remoteMsg) error {
+	if len(t.argv) == 0 {
+		return errors.New("no agent command")
+	}
+	cmd := exec.CommandContext(ctx, t.argv[0], t.argv[1:]...)
+	if len(t.env) > 0 {
+		cmd.Env = append(os.Environ(), t.env...)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(stdout)
+	var streamErr error
+	for {
+		var frame agentFrame
+		if err := dec.Decode(&frame); err != nil {
+			if !errors.Is(err, io.EOF) {
+				streamErr = fmt.Errorf("bad agent output: %w", err)
+			}
+			break
+		}
+		if frame.Version != agentProtocolVersion {
+			streamErr = fmt.Errorf("agent protocol v%d, want v%d; update mo on %s", frame.Version, agentProtocolVersion, t.name)
+			break
+		}
+		select {
+		case out <- remoteMsg{host: host, data: frame.Snapshot}:
+		case <-ctx.Done():
+		}
+	}
+	// Stop the agent if we bailed out early, then reap it.
+	if cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+	waitErr := cmd.Wait()
+
+	if streamErr != nil {
+		return streamErr
+	}
+	if msg := lastLine(stderr.String()); msg != "" {
+		return errors.New(msg)
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	return errors.New("agent exited")
+}
+
+// runRemote keeps a host connected, reporting failures and reconnecting.
+func runRemote(ctx context.Context, t commandTransport, host int, out chan<- remoteMsg) {
+	for {
+		err := t.stream(ctx, host, out)
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case out <- remoteMsg{host: host, err: err}:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(remoteRetryDelay):
+		}
+	}
+}
+
+func waitRemote(ch <-chan remoteMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// remoteHost is the latest state of one remote machine in the dashboard.
+type remoteHost struct {
+	name       string
+	metrics    MetricsSnapshot
+	errMessage string
+	ready      bool
+	pending    *remoteMsg // Latest message received while paused
+}
+
+// renderHostTabs draws the host switcher shown when remote hosts are attached.
+func renderHostTabs(names []string, selected int) string {
+	var parts []string
+	for i, name := range names {
+		label := fmt.Sprintf("%d %s", i+1, name)
+		if i == selected {
+			parts = append(parts, titleStyle.Render("● "+label))
+		} else {
+			parts = append(parts, subtleStyle.Render("○ "+label))
+		}
+	}
+	return strings.Join(parts, "  ") + subtleStyle.Render("   tab switch")
+}
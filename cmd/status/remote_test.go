@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestHelperAgent is not a real test: the transport tests run the test binary
+// with MOLE_HELPER_AGENT set so it behaves like `mo status --agent`.
+func TestHelperAgent(t *testing.T) {
+	mode := os.Getenv("MOLE_HELPER_AGENT")
+	if mode == "" {
+		t.Skip("helper process")
+	}
+	switch mode {
+	case "fail":
+		os.Stderr.WriteString("Permission denied (publickey).\n")
+		os.Exit(255)
+	case "old":
+		os.Stdout.WriteString(`{"v":0,"snapshot":{}}` + "\n")
+		os.Exit(0)
+	}
+	c := newTestCollector(&funcSource[string]{
+		name:    "host",
+		collect: func(context.Context, time.Time) (string, error) { return "helper-host", nil },
+		apply:   func(s *MetricsSnapshot, v string) { s.Host = v },
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := runAgent(ctx, c, os.Stdout, 10*time.Millisecond); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func helperTransport(mode string) commandTransport {
+	return commandTransport{
+		name: "helper",
+		argv: []string{os.Args[0], "-test.run=^TestHelperAgent$"},
+		env:  []string{"MOLE_HELPER_AGENT=" + mode},
+	}
+}
+
+func TestCommandTransportStreamsSnapshots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan remoteMsg)
+	done := make(chan error, 1)
+	go func() { done <- helperTransport("ok").stream(ctx, 3, out) }()
+
+	for range 2 {
+		select {
+		case msg := <-out:
+			if msg.host != 3 || msg.err != nil || msg.data.Host != "helper-host" {
+				t.Fatalf("remote message = %+v", msg)
+			}
+			if _, ok := msg.data.Sources["host"]; !ok {
+				t.Errorf("source status lost in transit: %+v", msg.data.Sources)
+			}
+		case err := <-done:
+			t.Fatalf("stream ended early: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no snapshot from agent")
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not stop after cancel")
+	}
+}
+
+func TestCommandTransportReportsErrors(t *testing.T) {
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{"fail", "Permission denied"},
+		{"old", "agent protocol v0"},
+	}
+	for _, tt := range tests {
+		err := helperTransport(tt.mode).stream(context.Background(), 0, make(chan remoteMsg))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: stream error = %v, want %q", tt.mode, err, tt.want)
+		}
+	}
+}
+
+func TestSSHTransportCommand(t *testing.T) {
+	got := strings.Join(sshTransport("web1", defaultRemoteCommand).argv, " ")
+	if !strings.HasPrefix(got, "ssh ") || !strings.HasSuffix(got, " -- web1 mo status --agent") {
+		t.Errorf("ssh argv = %q", got)
+	}
+}
+
+func TestModelSwitchesHosts(t *testing.T) {
+	m := model{collector: newTestCollector(), ready: true, width: 100}
+	m.metrics.Uptime = "9d"
+	m.hosts = []remoteHost{{name: "web1"}, {name: "db1"}}
+	m.remoteCh = make(chan remoteMsg)
+
+	next, _ := m.Update(remoteMsg{host: 1, data: MetricsSnapshot{Uptime: "2h"}})
+	m = next.(model)
+	next, _ = m.Update(remoteMsg{host: 0, err: os.ErrDeadlineExceeded})
+	m = next.(model)
+
+	press := func(key string) {
+		var msg tea.KeyMsg
+		if key == "tab" {
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		} else {
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		next, _ := m.Update(msg)
+		m = next.(model)
+	}
+
+	press("tab")
+	if m.hostIdx != 1 || !strings.Contains(m.View(), "web1 unreachable") {
+		t.Errorf("host 1 view = %q", stripANSI(m.View()))
+	}
+	press("3")
+	if view := stripANSI(m.View()); m.hostIdx != 2 || !strings.Contains(view, "up 2h") {
+		t.Errorf("host 2 view = %q", view)
+	}
+	press("tab")
+	if view := stripANSI(m.View()); m.hostIdx != 0 || !strings.Contains(view, "up 9d") {
+		t.Errorf("wrapped to local view = %q", view)
+	}
+}
+
+// Frames that arrive while paused are held, and the latest one is shown
+// on the next step.
+func TestPausedRemoteFramesAreKept(t *testing.T) {
+	m := model{collector: newTestCollector(), ready: true, paused: true, width: 100}
+	m.hosts = []remoteHost{{name: "web1"}}
+	m.remoteCh = make(chan remoteMsg)
+
+	for _, uptime := range []string{"1h", "2h"} {
+		next, _ := m.Update(remoteMsg{host: 0, data: MetricsSnapshot{Uptime: uptime}})
+		m = next.(model)
+	}
+	if m.hosts[0].ready {
+		t.Fatal("paused dashboard showed a remote frame")
+	}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(".")})
+	m = next.(model)
+	if h := m.hosts[0]; !h.ready || h.metrics.Uptime != "2h" || h.pending != nil {
+		t.Errorf("after step host = %+v", h)
+	}
+}
+
+func TestRemoteProcessPanelRefusesSignals(t *testing.T) {
+	m := model{
+		collector: newTestCollector(),
+		hosts:     []remoteHost{{name: "web1", ready: true, metrics: MetricsSnapshot{Processes: []ProcessInfo{{PID: 42, Name: "nginx"}}}}},
+		hostIdx:   1,
+	}
+	m.procPanel.open = true
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = next.(model)
+	if cmd != nil || m.procPanel.confirm != nil || !strings.Contains(m.procPanel.message, "local processes") {
+		t.Errorf("remote signal not refused: confirm=%v message=%q", m.procPanel.confirm, m.procPanel.message)
+	}
+}
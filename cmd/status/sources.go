@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricSource is one independently refreshed data source of the dashboard.
+type MetricSource interface {
+	// Name identifies the source in errors and configuration.
+	Name() string
+	// Interval is the minimum time between collections; zero means every refresh.
+	Interval() time.Duration
+	// Timeout bounds a single collection.
+	Timeout() time.Duration
+	// Collect gathers fresh data on its own goroutine. On error the previous
+	// value is kept.
+	Collect(ctx context.Context, now time.Time) error
+	// Apply copies the latest collected value into the snapshot.
+	Apply(s *MetricsSnapshot)
+	// Card renders the source's dashboard card; ok is false when it has none.
+	Card(s MetricsSnapshot, width int) (card cardData, ok bool)
+}
+
+const defaultSourceTimeout = 2 * time.Second
+
+// sourceFactories holds every registered source in card order.
+var sourceFactories []func(c *Collector) MetricSource
+
+// RegisterSource adds a source to every Collector created afterwards.
+// Cards are laid out in registration order.
+func RegisterSource(factory func(c *Collector) MetricSource) {
+	sourceFactories = append(sourceFactories, factory)
+}
+
+// registeredSourceNames lists the registered sources in card order.
+func registeredSourceNames() []string {
+	c := &Collector{}
+	names := make([]string, len(sourceFactories))
+	for i, factory := range sourceFactories {
+		names[i] = factory(c).Name()
+	}
+	return names
+}
+
+// cardDependencies is implemented by sources whose card also renders data
+// collected by other sources, so the card reflects their staleness too.
+type cardDependencies interface {
+	CardUses() []string
+}
+
+// funcSource adapts plain functions to MetricSource. Any of collect, apply and
+// card may be nil, e.g. for a card rendered from data another source collects.
+type funcSource[T any] struct {
+	name     string
+	interval time.Duration
+	timeout  time.Duration
+	uses     []string // Other sources the card renders
+	collect  func(ctx context.Context, now time.Time) (T, error)
+	apply    func(s *MetricsSnapshot, v T)
+	card     func(s MetricsSnapshot, width int) cardData
+	visible  func(s MetricsSnapshot) bool                        // Hides the card when false; nil always shows it
+	zoom     func(s MetricsSnapshot, width, height int) cardData // Full-screen card; nil widens the regular one
+
+	mu    sync.Mutex
+	value T
+}
+
+func (f *funcSource[T]) Name() string            { return f.name }
+func (f *funcSource[T]) Interval() time.Duration { return f.interval }
+func (f *funcSource[T]) CardUses() []string      { return f.uses }
+
+func (f *funcSource[T]) Timeout() time.Duration {
+	if f.timeout <= 0 {
+		return defaultSourceTimeout
+	}
+	return f.timeout
+}
+
+func (f *funcSource[T]) Collect(ctx context.Context, now time.Time) error {
+	if f.collect == nil {
+		return nil
+	}
+	v, err := f.collect(ctx, now)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.value = v
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *funcSource[T]) Apply(s *MetricsSnapshot) {
+	if f.apply == nil {
+		return
+	}
+	f.mu.Lock()
+	v := f.value
+	f.mu.Unlock()
+	f.apply(s, v)
+}
+
+func (f *funcSource[T]) Card(s MetricsSnapshot, width int) (cardData, bool) {
+	if f.card == nil || (f.visible != nil && !f.visible(s)) {
+		return cardData{}, false
+	}
+	return f.card(s, width), true
+}
+
+func (f *funcSource[T]) ZoomCard(s MetricsSnapshot, width, height int) (cardData, bool) {
+	if f.zoom == nil {
+		return f.Card(s, width)
+	}
+	if f.visible != nil && !f.visible(s) {
+		return cardData{}, false
+	}
+	return f.zoom(s, width, height), true
+}
+
+// SourceStatus reports how fresh one source's data in a snapshot is.
+type SourceStatus struct {
+	UpdatedAt time.Time     // When the current value was collected
+	Latency   time.Duration // Duration of the last collection attempt
+	Stale     bool          // The last attempt failed; the value is from an earlier refresh
+	Err       string        // Why the last attempt failed
+}
+
+// Failing reports whether the source has no usable value at all.
+func (s SourceStatus) Failing() bool {
+	return s.Err != "" && !s.Stale
+}
+
+// sourceState tracks scheduling and health for one source.
+type sourceState struct {
+	lastRun time.Time
+	running bool
+	status  SourceStatus
+}
+
+// due reports whether src should be collected at now and marks it running.
+func (c *Collector) due(src MetricSource, now time.Time) bool {
+	c.sourceMu.Lock()
+	defer c.sourceMu.Unlock()
+	st := c.sourceState[src.Name()]
+	if st == nil {
+		st = &sourceState{}
+		c.sourceState[src.Name()] = st
+	}
+	// A collection that outlived its timeout is still running; don't pile up.
+	if st.running {
+		return false
+	}
+	if !st.lastRun.IsZero() && now.Sub(st.lastRun) < src.Interval() {
+		return false
+	}
+	st.running = true
+	st.lastRun = now
+	return true
+}
+
+// finish records the outcome of a collection, including one that completes
+// after its deadline was reported.
+func (c *Collector) finish(src MetricSource, started time.Time, err error) {
+	c.sourceMu.Lock()
+	defer c.sourceMu.Unlock()
+	st := c.sourceState[src.Name()]
+	st.running = false
+	st.status.Latency = time.Since(started)
+	if err != nil {
+		st.status.Err = err.Error()
+		st.status.Stale = !st.status.UpdatedAt.IsZero()
+		return
+	}
+	st.status.UpdatedAt = started
+	st.status.Err = ""
+	st.status.Stale = false
+}
+
+// timedOut marks src stale while its collection is still running.
+func (c *Collector) timedOut(src MetricSource, err error) {
+	c.sourceMu.Lock()
+	defer c.sourceMu.Unlock()
+	st := c.sourceState[src.Name()]
+	if !st.running {
+		return // Finished just after the deadline.
+	}
+	st.status.Latency = src.Timeout()
+	st.status.Err = err.Error()
+	st.status.Stale = !st.status.UpdatedAt.IsZero()
+}
+
+// statuses returns a copy of every source's status.
+func (c *Collector) statuses() map[string]SourceStatus {
+	c.sourceMu.Lock()
+	defer c.sourceMu.Unlock()
+	out := make(map[string]SourceStatus, len(c.sourceState))
+	for name, st := range c.sourceState {
+		out[name] = st.status
+	}
+	return out
+}
+
+// runSource collects src with its own deadline, isolating panics. A timed-out
+// collection keeps running in the background; the previous value is served
+// as stale until it lands.
+func (c *Collector) runSource(src MetricSource, now time.Time) error {
+	ctx := context.Background()
+	if c.platform != nil {
+		ctx = withPlatform(ctx, c.platform)
+	}
+	if c.record != nil {
+		ctx = withTapeSource(ctx, c.record.tape, src.Name())
+	}
+	ctx, cancel := context.WithTimeout(ctx, src.Timeout())
+	done := make(chan error, 1)
+	go func() {
+		defer cancel()
+		started := time.Now()
+		err := collectSafely(ctx, src, now)
+		c.finish(src, started, err)
+		done <- err
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// ctx is also cancelled when Collect returns, so prefer its result.
+		select {
+		case err = <-done:
+		default:
+			err = fmt.Errorf("timed out after %s", src.Timeout())
+			c.timedOut(src, err)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", src.Name(), err)
+	}
+	return nil
+}
+
+func collectSafely(ctx context.Context, src MetricSource, now time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return src.Collect(ctx, now)
+}
+
+// buildCards renders the cards of every source that has one, in source order,
+// badged when their data is stale or missing.
+func buildCards(sources []MetricSource, m MetricsSnapshot, width int) []cardData {
+	var cards []cardData
+	for _, src := range sources {
+		card, ok := src.Card(m, width)
+		if !ok {
+			continue
+		}
+		card.name = src.Name()
+		card.badge = cardBadge(src, m.Sources)
+		cards = append(cards, card)
+	}
+	return cards
+}
+
+// cardBadge marks a source's card when it or a source it renders is stale or
+// failing.
+func cardBadge(src MetricSource, statuses map[string]SourceStatus) string {
+	names := []string{src.Name()}
+	if deps, ok := src.(cardDependencies); ok {
+		names = append(names, deps.CardUses()...)
+	}
+	return sourceBadge(statuses, names)
+}
+
+func sourceBadge(statuses map[string]SourceStatus, names []string) string {
+	stale := false
+	for _, name := range names {
+		st := statuses[name]
+		if st.Failing() {
+			return dangerStyle.Render("✕ failed")
+		}
+		stale = stale || st.Stale
+	}
+	if stale {
+		return warnStyle.Render("◌ stale")
+	}
+	return ""
+}
+
+// sourceIssues summarizes stale and failing sources for the header.
+func sourceIssues(statuses map[string]SourceStatus) string {
+	var stale, failed []string
+	for name, st := range statuses {
+		switch {
+		case st.Failing():
+			failed = append(failed, name)
+		case st.Stale:
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	sort.Strings(failed)
+	var parts []string
+	if len(failed) > 0 {
+		parts = append(parts, "Failed: "+strings.Join(failed, ", "))
+	}
+	if len(stale) > 0 {
+		parts = append(parts, "Stale: "+strings.Join(stale, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
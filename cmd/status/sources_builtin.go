@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// networkData is the network source's value; history is copied out of the
+// collector's ring buffers while collecting so Apply never races with them.
+type networkData struct {
+	stats   []NetworkStatus
+	history NetworkHistory
+}
+
+// processData is the processes source's value; leaks are judged from RSS
+// history kept while collecting.
+type processData struct {
+	procs []ProcessInfo
+	leaks []LeakSuspect
+}
+
+// thermalData is the thermal source's value; on Linux both come from one
+// walk of hwmon and the thermal zones.
+type thermalData struct {
+	status  ThermalStatus
+	sensors []SensorReading
+}
+
+type hostData struct {
+	name     string
+	platform string
+	uptime   string
+	procs    uint64
+}
+
+func init() {
+	// Sources with cards, in dashboard order.
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[CPUStatus]{
+			name: "cpu",
+			uses: []string{"thermal", "cgroup"},
+			collect: func(ctx context.Context, _ time.Time) (CPUStatus, error) {
+				return c.collectCPU(ctx)
+			},
+			apply: func(s *MetricsSnapshot, v CPUStatus) { s.CPU = v },
+			card:  func(s MetricsSnapshot, _ int) cardData { return renderCPUCard(s.CPU, s.Thermal, s.Cgroup) },
+			zoom: func(s MetricsSnapshot, width, height int) cardData {
+				return renderCPUZoom(s.CPU, s.Thermal, s.Cgroup, width, height)
+			},
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[MemoryStatus]{
+			name: "memory",
+			uses: []string{"cgroup", "processes"},
+			collect: func(ctx context.Context, now time.Time) (MemoryStatus, error) {
+				return c.collectMemory(ctx, now)
+			},
+			apply: func(s *MetricsSnapshot, v MemoryStatus) {
+				// The processes source fills Top, whichever applies first.
+				v.Top = s.Memory.Top
+				s.Memory = v
+			},
+			card: func(s MetricsSnapshot, _ int) cardData { return renderMemoryCard(s.Memory, s.Cgroup) },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[[]GPUStatus]{
+			name:    "gpu",
+			timeout: systemProfilerTimeout + powermetricsTimeout,
+			collect: func(ctx context.Context, now time.Time) ([]GPUStatus, error) {
+				return c.collectGPU(ctx, now)
+			},
+			apply:   func(s *MetricsSnapshot, v []GPUStatus) { s.GPU = v },
+			card:    func(s MetricsSnapshot, _ int) cardData { return renderGPUCard(s.GPU) },
+			visible: func(s MetricsSnapshot) bool { return hasGPUData(s.GPU) },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[[]DiskStatus]{
+			name:    "disks",
+			timeout: 3 * time.Second,
+			uses:    []string{"diskio"},
+			collect: func(ctx context.Context, _ time.Time) ([]DiskStatus, error) {
+				return collectDisks(ctx)
+			},
+			apply: func(s *MetricsSnapshot, v []DiskStatus) { s.Disks = v },
+			card:  func(s MetricsSnapshot, _ int) cardData { return renderDiskCard(s.Disks, s.DiskIO) },
+			zoom: func(s MetricsSnapshot, width, height int) cardData {
+				return cardData{icon: iconDisk, title: "Disk", lines: diskTableLines(s.Disks, s.DiskIO, width, height-1)}
+			},
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[[]DriveHealth]{
+			name:     "drives",
+			interval: driveHealthInterval,
+			timeout:  3 * smartctlTimeout,
+			collect: func(ctx context.Context, _ time.Time) ([]DriveHealth, error) {
+				return collectDriveHealth(ctx)
+			},
+			apply:   func(s *MetricsSnapshot, v []DriveHealth) { s.Drives = v },
+			card:    func(s MetricsSnapshot, _ int) cardData { return renderDriveHealthCard(s.Drives) },
+			visible: func(s MetricsSnapshot) bool { return len(s.Drives) > 0 },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[[]BatteryStatus]{
+			name:    "batteries",
+			timeout: 5 * time.Second,
+			uses:    []string{"thermal"},
+			collect: func(ctx context.Context, _ time.Time) ([]BatteryStatus, error) {
+				// No battery is a normal state, not an error.
+				batts, _ := collectBatteries(ctx)
+				return batts, nil
+			},
+			apply: func(s *MetricsSnapshot, v []BatteryStatus) { s.Batteries = v },
+			card:  func(s MetricsSnapshot, _ int) cardData { return renderBatteryCard(s.Batteries, s.Thermal) },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		// Read by the thermal source along with the temperatures it summarizes.
+		return &funcSource[struct{}]{
+			name:    "sensors",
+			uses:    []string{"thermal"},
+			card:    func(s MetricsSnapshot, _ int) cardData { return renderSensorsCard(s.Sensors) },
+			visible: func(s MetricsSnapshot) bool { return hasSensorData(s.Sensors) },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[processData]{
+			name:    "processes",
+			timeout: processTimeout + time.Second,
+			collect: func(ctx context.Context, now time.Time) (processData, error) {
+				procs, err := c.collectProcesses(ctx, now)
+				if err != nil {
+					return processData{}, err
+				}
+				return processData{procs: procs, leaks: c.trackRSS(procs, now)}, nil
+			},
+			apply: func(s *MetricsSnapshot, v processData) {
+				s.Processes = v.procs
+				s.TopProcesses = topProcesses(v.procs, topProcessCount)
+				s.TopIO = topIOProcesses(v.procs, topIOCount)
+				s.Memory.Top = topMemoryConsumers(v.procs, topMemoryCount)
+				s.Leaks = v.leaks
+			},
+			card: func(s MetricsSnapshot, _ int) cardData { return renderProcessCard(s.TopProcesses, processCardRows) },
+			zoom: func(s MetricsSnapshot, _, height int) cardData {
+				return renderProcessCard(topProcesses(s.Processes, height-1), height-1)
+			},
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[networkData]{
+			name: "network",
+			uses: []string{"proxy", "probes"},
+			collect: func(ctx context.Context, now time.Time) (networkData, error) {
+				stats, err := c.collectNetwork(ctx, now)
+				if err != nil {
+					return networkData{}, err
+				}
+				return networkData{
+					stats: stats,
+					history: NetworkHistory{
+						RxHistory: c.rxHistoryBuf.Slice(),
+						TxHistory: c.txHistoryBuf.Slice(),
+					},
+				}, nil
+			},
+			apply: func(s *MetricsSnapshot, v networkData) {
+				s.Network = v.stats
+				s.NetworkHistory = v.history
+			},
+			card: func(s MetricsSnapshot, width int) cardData {
+				return renderNetworkCard(s.Network, s.NetworkHistory, s.Proxy, s.Probes, width)
+			},
+			zoom: func(s MetricsSnapshot, width, _ int) cardData {
+				return renderNetworkZoom(s.Network, s.NetworkHistory, s.Proxy, s.Probes, width)
+			},
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[[]ProbeStatus]{
+			name:     "probes",
+			interval: probeInterval,
+			timeout:  probeTimeout + time.Second,
+			collect: func(ctx context.Context, _ time.Time) ([]ProbeStatus, error) {
+				return c.collectProbes(ctx, probeSpecs), nil
+			},
+			apply: func(s *MetricsSnapshot, v []ProbeStatus) { s.Probes = v },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[CgroupStatus]{
+			name: "cgroup",
+			collect: func(ctx context.Context, now time.Time) (CgroupStatus, error) {
+				return c.collectCgroup(ctx, now)
+			},
+			apply:   func(s *MetricsSnapshot, v CgroupStatus) { s.Cgroup = v },
+			card:    func(s MetricsSnapshot, _ int) cardData { return renderCgroupCard(s.Cgroup) },
+			visible: func(s MetricsSnapshot) bool { return showCgroupCard && s.Cgroup.Version > 0 },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		// Rendered from per-process counters gathered by the processes source.
+		return &funcSource[struct{}]{
+			name: "topio",
+			uses: []string{"processes"},
+			card: func(s MetricsSnapshot, _ int) cardData { return renderTopIOCard(s.TopIO) },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		// Judged from RSS history kept by the processes source.
+		return &funcSource[struct{}]{
+			name:    "leaks",
+			uses:    []string{"processes"},
+			card:    func(s MetricsSnapshot, _ int) cardData { return renderLeakCard(s.Leaks, s.CollectedAt) },
+			visible: func(s MetricsSnapshot) bool { return len(s.Leaks) > 0 },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[ConnectionStatus]{
+			name:     "connections",
+			interval: connInterval,
+			timeout:  connTimeout,
+			collect: func(ctx context.Context, _ time.Time) (ConnectionStatus, error) {
+				return collectConnections(ctx)
+			},
+			apply: func(s *MetricsSnapshot, v ConnectionStatus) { s.Connections = v },
+			card:  func(s MetricsSnapshot, _ int) cardData { return renderConnectionsCard(s.Connections, listenCardRows) },
+			zoom: func(s MetricsSnapshot, _, height int) cardData {
+				return renderConnectionsCard(s.Connections, max(height-4, listenCardRows))
+			},
+		}
+	})
+
+	// Sources feeding other cards, the header and the health score.
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[DiskIOStatus]{
+			name: "diskio",
+			collect: func(ctx context.Context, now time.Time) (DiskIOStatus, error) {
+				return c.collectDiskIO(ctx, now), nil
+			},
+			apply: func(s *MetricsSnapshot, v DiskIOStatus) { s.DiskIO = v },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[ProxyStatus]{
+			name: "proxy",
+			collect: func(ctx context.Context, _ time.Time) (ProxyStatus, error) {
+				return collectProxy(ctx), nil
+			},
+			apply: func(s *MetricsSnapshot, v ProxyStatus) { s.Proxy = v },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[thermalData]{
+			name:    "thermal",
+			timeout: 5 * time.Second,
+			collect: func(ctx context.Context, _ time.Time) (thermalData, error) {
+				sensors, err := collectSensors(ctx)
+				if err != nil {
+					return thermalData{}, err
+				}
+				return thermalData{status: collectThermal(ctx, sensors), sensors: sensors}, nil
+			},
+			apply: func(s *MetricsSnapshot, v thermalData) {
+				s.Thermal = v.status
+				s.Sensors = v.sensors
+			},
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[[]BluetoothDevice]{
+			name:     "bluetooth",
+			interval: bluetoothRefreshInterval,
+			timeout:  systemProfilerTimeout + time.Second,
+			collect: func(ctx context.Context, _ time.Time) ([]BluetoothDevice, error) {
+				return collectBluetooth(ctx), nil
+			},
+			apply: func(s *MetricsSnapshot, v []BluetoothDevice) { s.Bluetooth = v },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[HardwareInfo]{
+			name:     "hardware",
+			interval: hardwareRefreshInterval,
+			timeout:  8 * time.Second,
+			collect: func(ctx context.Context, _ time.Time) (HardwareInfo, error) {
+				var totalRAM uint64
+				if vm, err := platformFrom(ctx).virtualMemory(ctx); err == nil {
+					totalRAM = vm.Total
+				}
+				disks, _ := collectDisks(ctx)
+				return collectHardware(ctx, totalRAM, disks), nil
+			},
+			apply: func(s *MetricsSnapshot, v HardwareInfo) { s.Hardware = v },
+		}
+	})
+	RegisterSource(func(c *Collector) MetricSource {
+		return &funcSource[hostData]{
+			name: "host",
+			collect: func(ctx context.Context, _ time.Time) (hostData, error) {
+				info, err := platformFrom(ctx).hostInfo(ctx)
+				if err != nil {
+					return hostData{}, err
+				}
+				return hostData{
+					name:     info.Hostname,
+					platform: fmt.Sprintf("%s %s", info.Platform, info.PlatformVersion),
+					uptime:   formatUptime(info.Uptime),
+					procs:    info.Procs,
+				}, nil
+			},
+			apply: func(s *MetricsSnapshot, v hostData) {
+				s.Host = v.name
+				s.Platform = v.platform
+				s.Uptime = v.uptime
+				s.Procs = v.procs
+			},
+		}
+	})
+}
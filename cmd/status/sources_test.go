@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestCollector(sources ...MetricSource) *Collector {
+	return &Collector{
+		sources:     sources,
+		sourceState: make(map[string]*sourceState),
+	}
+}
+
+func TestCollectRespectsSourceInterval(t *testing.T) {
+	var calls atomic.Int32
+	src := &funcSource[int]{
+		name:     "slowchanging",
+		interval: time.Hour,
+		collect: func(context.Context, time.Time) (int, error) {
+			return int(calls.Add(1)), nil
+		},
+		apply: func(s *MetricsSnapshot, v int) { s.Procs = uint64(v) },
+	}
+	c := newTestCollector(src)
+
+	for range 3 {
+		snap, err := c.Collect()
+		if err != nil {
+			t.Fatalf("Collect() error = %v", err)
+		}
+		if snap.Procs != 1 {
+			t.Fatalf("snapshot value = %d, want cached 1", snap.Procs)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("collect called %d times, want 1", got)
+	}
+}
+
+func TestCollectTimeoutKeepsPreviousValue(t *testing.T) {
+	var block atomic.Bool
+	release := make(chan struct{})
+	src := &funcSource[string]{
+		name:    "hang",
+		timeout: 20 * time.Millisecond,
+		collect: func(context.Context, time.Time) (string, error) {
+			if block.Load() {
+				<-release
+				return "late", nil
+			}
+			return "fresh", nil
+		},
+		apply: func(s *MetricsSnapshot, v string) { s.Host = v },
+	}
+	fast := &funcSource[string]{
+		name:    "fast",
+		collect: func(context.Context, time.Time) (string, error) { return "ok", nil },
+		apply:   func(s *MetricsSnapshot, v string) { s.Platform = v },
+	}
+	c := newTestCollector(src, fast)
+
+	if snap, err := c.Collect(); err != nil || snap.Host != "fresh" {
+		t.Fatalf("first Collect() = %q, %v", snap.Host, err)
+	}
+
+	block.Store(true)
+	start := time.Now()
+	snap, err := c.Collect()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Collect() blocked for %s despite source timeout", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "hang: timed out") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if snap.Host != "fresh" || snap.Platform != "ok" {
+		t.Errorf("snapshot = %q/%q, want previous value and other sources intact", snap.Host, snap.Platform)
+	}
+
+	// The hung collection is still running, so it must not be started again.
+	if _, err := c.Collect(); err != nil {
+		t.Errorf("Collect() while previous run in flight = %v, want nil", err)
+	}
+	close(release)
+}
+
+func TestCollectIsolatesPanics(t *testing.T) {
+	bad := &funcSource[int]{
+		name:    "broken",
+		collect: func(context.Context, time.Time) (int, error) { panic("boom") },
+	}
+	good := &funcSource[string]{
+		name:    "good",
+		collect: func(context.Context, time.Time) (string, error) { return "alive", nil },
+		apply:   func(s *MetricsSnapshot, v string) { s.Host = v },
+	}
+	snap, err := newTestCollector(bad, good).Collect()
+	if err == nil || !strings.Contains(err.Error(), "broken: panic: boom") {
+		t.Errorf("expected panic error, got %v", err)
+	}
+	if snap.Host != "alive" {
+		t.Errorf("healthy source lost its value: %q", snap.Host)
+	}
+}
+
+func TestBuildCardsFollowsSourceOrder(t *testing.T) {
+	card := func(title string) func(MetricsSnapshot, int) cardData {
+		return func(MetricsSnapshot, int) cardData { return cardData{title: title} }
+	}
+	sources := []MetricSource{
+		&funcSource[int]{name: "b", card: card("B")},
+		&funcSource[int]{name: "hidden"},
+		&funcSource[int]{name: "a", card: card("A")},
+	}
+	cards := buildCards(sources, MetricsSnapshot{}, 40)
+	if len(cards) != 2 || cards[0].title != "B" || cards[1].title != "A" {
+		t.Errorf("buildCards = %+v, want B then A", cards)
+	}
+}
+
+func TestBuiltinSourcesHaveUniqueNames(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, src := range NewCollector().Sources() {
+		if seen[src.Name()] {
+			t.Errorf("duplicate source name %q", src.Name())
+		}
+		seen[src.Name()] = true
+	}
+}
+
+func TestCollectReportsSourceStatus(t *testing.T) {
+	var fail atomic.Bool
+	release := make(chan struct{})
+	var hang atomic.Bool
+	src := &funcSource[string]{
+		name:    "flaky",
+		timeout: 20 * time.Millisecond,
+		collect: func(context.Context, time.Time) (string, error) {
+			if hang.Load() {
+				<-release
+				return "recovered", nil
+			}
+			if fail.Load() {
+				return "", errors.New("ioreg exited 1")
+			}
+			return "ok", nil
+		},
+		apply: func(s *MetricsSnapshot, v string) { s.Host = v },
+	}
+	never := &funcSource[string]{
+		name:    "never",
+		collect: func(context.Context, time.Time) (string, error) { return "", errors.New("unsupported") },
+	}
+	c := newTestCollector(src, never)
+
+	snap, _ := c.Collect()
+	if st := snap.Sources["flaky"]; st.Stale || st.Err != "" || st.UpdatedAt.IsZero() {
+		t.Fatalf("healthy status = %+v", st)
+	}
+	if st := snap.Sources["never"]; !st.Failing() {
+		t.Errorf("source without any value should be failing, got %+v", st)
+	}
+
+	fail.Store(true)
+	snap, _ = c.Collect()
+	if st := snap.Sources["flaky"]; !st.Stale || st.Err != "ioreg exited 1" || st.Failing() {
+		t.Errorf("failed refresh status = %+v, want stale with error", st)
+	}
+	if snap.Host != "ok" {
+		t.Errorf("stale value = %q, want previous \"ok\"", snap.Host)
+	}
+
+	hang.Store(true)
+	snap, _ = c.Collect()
+	st := snap.Sources["flaky"]
+	if !st.Stale || !strings.Contains(st.Err, "timed out") || st.Latency != 20*time.Millisecond {
+		t.Errorf("timed out status = %+v", st)
+	}
+
+	// The late result replaces the stale value once it lands.
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		snap, _ = c.Collect()
+		if snap.Host == "recovered" {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if st := snap.Sources["flaky"]; snap.Host != "recovered" || st.Stale || st.Err != "" {
+		t.Errorf("after late completion: host=%q status=%+v", snap.Host, st)
+	}
+}
+
+func TestSourceBadgeAndIssues(t *testing.T) {
+	statuses := map[string]SourceStatus{
+		"cpu":     {},
+		"thermal": {Stale: true, Err: "timed out"},
+		"gpu":     {Err: "nvidia-smi missing"},
+	}
+	if got := sourceBadge(statuses, []string{"cpu"}); got != "" {
+		t.Errorf("healthy badge = %q", got)
+	}
+	if got := stripANSI(sourceBadge(statuses, []string{"cpu", "thermal"})); got != "◌ stale" {
+		t.Errorf("dependency badge = %q, want stale", got)
+	}
+	if got := stripANSI(sourceBadge(statuses, []string{"gpu", "thermal"})); got != "✕ failed" {
+		t.Errorf("failing badge = %q", got)
+	}
+	if got := sourceIssues(statuses); got != "Failed: gpu · Stale: thermal" {
+		t.Errorf("sourceIssues = %q", got)
+	}
+}
@@ -2,24 +2,60 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tw93/mole/internal/theme"
+	"github.com/tw93/mole/internal/units"
 )
 
 var (
-	titleStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#C79FD7")).Bold(true)
-	subtleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#737373"))
-	warnStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD75F"))
-	dangerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F5F")).Bold(true)
-	okStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#A5D6A7"))
-	lineStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#404040"))
-
-	primaryStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#BD93F9"))
+	// activeTheme is set once at startup by applyTheme.
+	activeTheme = theme.Dark
+	// unitFmt is set once at startup from the units preferences.
+	unitFmt = units.Default()
+
+	titleStyle    lipgloss.Style
+	subtleStyle   lipgloss.Style
+	warnStyle     lipgloss.Style
+	dangerStyle   lipgloss.Style
+	okStyle       lipgloss.Style
+	lineStyle     lipgloss.Style
+	primaryStyle  lipgloss.Style
+	selectedStyle lipgloss.Style
 )
 
+func init() {
+	applyTheme(activeTheme)
+}
+
+// resolveTheme picks the theme for the configured name. "auto" defers to
+// MO_THEME, which mo analyze reads too.
+func resolveTheme(name string) theme.Theme {
+	if name == "auto" || name == "" {
+		name = os.Getenv("MO_THEME")
+	}
+	return theme.Resolve(name)
+}
+
+// applyTheme points the shared styles at t's palette.
+func applyTheme(t theme.Theme) {
+	activeTheme = t
+	titleStyle = t.Style(theme.Title)
+	subtleStyle = t.Style(theme.Subtle)
+	warnStyle = t.Style(theme.Warn)
+	dangerStyle = t.Style(theme.Danger).Bold(true)
+	okStyle = t.Style(theme.OK)
+	lineStyle = t.Style(theme.Line)
+	primaryStyle = t.Style(theme.Primary)
+	selectedStyle = t.Style(theme.Selected)
+}
+
 const (
 	colWidth    = 38
 	iconCPU     = "◉"
@@ -30,6 +66,9 @@ const (
 	iconBattery = "◪"
 	iconSensors = "◈"
 	iconProcs   = "❊"
+	iconIO      = "⇵"
+	iconConns   = "⇄"
+	iconLeaks   = "◬"
 )
 
 // Mole body frames (facing right).
@@ -125,9 +164,12 @@ func getMoleFrame(animFrame int, termWidth int) string {
 }
 
 type cardData struct {
-	icon  string
-	title string
-	lines []string
+	name    string // Source that rendered the card
+	icon    string
+	title   string
+	badge   string // Stale/failed marker shown after the title
+	lines   []string
+	focused bool
 }
 
 func renderHeader(m MetricsSnapshot, errMsg string, animFrame int, termWidth int, catHidden bool) string {
@@ -179,9 +221,9 @@ func renderHeader(m MetricsSnapshot, errMsg string, animFrame int, termWidth int
 
 	if errMsg != "" {
 		if mole == "" {
-			return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", dangerStyle.Render("ERROR: "+errMsg), "")
+			return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", warnStyle.Render("⚠ "+errMsg), "")
 		}
-		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", mole, dangerStyle.Render("ERROR: "+errMsg), "")
+		return lipgloss.JoinVertical(lipgloss.Left, headerLine, "", mole, warnStyle.Render("⚠ "+errMsg), "")
 	}
 	if mole == "" {
 		return headerLine
@@ -190,32 +232,22 @@ func renderHeader(m MetricsSnapshot, errMsg string, animFrame int, termWidth int
 }
 
 func getScoreStyle(score int) lipgloss.Style {
+	role := theme.Critical
 	switch {
 	case score >= 90:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#87FF87")).Bold(true)
+		role = theme.Good
 	case score >= 75:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#87D787")).Bold(true)
+		role = theme.Fair
 	case score >= 60:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD75F")).Bold(true)
+		role = theme.Warn
 	case score >= 40:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF5F")).Bold(true)
-	default:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Bold(true)
+		role = theme.Caution
 	}
+	return activeTheme.Style(role).Bold(true)
 }
 
-func renderCPUCard(cpu CPUStatus, thermal ThermalStatus) cardData {
-	var lines []string
-
-	// Line 1: Usage + Temp (Format: 15% @ 30.4°C)
-	usageBar := progressBar(cpu.Usage)
-
-	headerText := fmt.Sprintf("%5.1f%%", cpu.Usage)
-	if thermal.CPUTemp > 0 {
-		headerText += fmt.Sprintf(" @ %s°C", colorizeTemp(thermal.CPUTemp))
-	}
-
-	lines = append(lines, fmt.Sprintf("Total  %s  %s", usageBar, headerText))
+func renderCPUCard(cpu CPUStatus, thermal ThermalStatus, cg CgroupStatus) cardData {
+	lines := cpuTotalLines(cpu, thermal, cg)
 
 	if cpu.PerCoreEstimated {
 		lines = append(lines, subtleStyle.Render("Per-core data unavailable, using averaged load"))
@@ -237,7 +269,29 @@ func renderCPUCard(cpu CPUStatus, thermal ThermalStatus) cardData {
 		}
 	}
 
-	// Load line at the end
+	lines = append(lines, cpuLoadLines(cpu)...)
+	return cardData{icon: iconCPU, title: "CPU", lines: lines}
+}
+
+// cpuTotalLines is overall usage with the temperature (15% @ 30.4°C), then
+// the effective limit when confined by a cgroup CPU quota.
+func cpuTotalLines(cpu CPUStatus, thermal ThermalStatus, cg CgroupStatus) []string {
+	headerText := fmt.Sprintf("%5.1f%%", cpu.Usage)
+	if thermal.CPUTemp > 0 {
+		headerText += " @ " + colorizeTemp(thermal.CPUTemp)
+	}
+	lines := []string{fmt.Sprintf("Total  %s  %s", progressBar(cpu.Usage), headerText)}
+
+	if cg.CPULimit > 0 && (cpu.LogicalCPU == 0 || cg.CPULimit < float64(cpu.LogicalCPU)) {
+		limitPercent := cg.CPUUsage / cg.CPULimit * 100
+		lines = append(lines, fmt.Sprintf("Limit  %s  %5.1f%% of %.1f cores", progressBar(limitPercent), limitPercent, cg.CPULimit))
+	}
+	return lines
+}
+
+// cpuLoadLines is the load average with the core count, then PSI stall.
+func cpuLoadLines(cpu CPUStatus) []string {
+	var lines []string
 	if cpu.PCoreCount > 0 && cpu.ECoreCount > 0 {
 		lines = append(lines, fmt.Sprintf("Load   %.2f / %.2f / %.2f, %dP+%dE",
 			cpu.Load1, cpu.Load5, cpu.Load15, cpu.PCoreCount, cpu.ECoreCount))
@@ -245,11 +299,129 @@ func renderCPUCard(cpu CPUStatus, thermal ThermalStatus) cardData {
 		lines = append(lines, fmt.Sprintf("Load   %.2f / %.2f / %.2f, %d cores",
 			cpu.Load1, cpu.Load5, cpu.Load15, cpu.LogicalCPU))
 	}
+	if cpu.Stall > 0 {
+		lines = append(lines, formatStall(cpu.Stall))
+	}
+	return lines
+}
+
+// renderCPUZoom is the full-screen CPU card: clocks, where the time went,
+// performance and efficiency core averages, and every core's history.
+func renderCPUZoom(cpu CPUStatus, thermal ThermalStatus, cg CgroupStatus, width, height int) cardData {
+	lines := cpuTotalLines(cpu, thermal, cg)
+	if cpu.PCoreUsage > 0 || cpu.ECoreUsage > 0 {
+		lines = append(lines,
+			fmt.Sprintf("P-core %s  %5.1f%% of %d", progressBar(cpu.PCoreUsage), cpu.PCoreUsage, cpu.PCoreCount),
+			fmt.Sprintf("E-core %s  %5.1f%% of %d", progressBar(cpu.ECoreUsage), cpu.ECoreUsage, cpu.ECoreCount))
+	}
+	if line := formatCPUClock(cpu); line != "" {
+		lines = append(lines, line)
+	}
+	if line := formatCPUTimes(cpu.Times); line != "" {
+		lines = append(lines, line)
+	}
+	lines = append(lines, cpuLoadLines(cpu)...)
 
+	if cpu.PerCoreEstimated {
+		lines = append(lines, "", subtleStyle.Render("Per-core data unavailable, using averaged load"))
+	} else if len(cpu.PerCore) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, coreGridLines(cpu, width, height-1-len(lines))...)
+	}
 	return cardData{icon: iconCPU, title: "CPU", lines: lines}
 }
 
-func renderMemoryCard(mem MemoryStatus) cardData {
+// formatCPUClock shows the current and rated clock, whichever are known.
+func formatCPUClock(cpu CPUStatus) string {
+	ghz := func(mhz int) string { return fmt.Sprintf("%.2f GHz", float64(mhz)/1000) }
+	switch {
+	case cpu.FreqMHz > 0 && cpu.MaxFreqMHz > 0:
+		return fmt.Sprintf("Clock  %s of %s max", ghz(cpu.FreqMHz), ghz(cpu.MaxFreqMHz))
+	case cpu.FreqMHz > 0:
+		return "Clock  " + ghz(cpu.FreqMHz)
+	case cpu.MaxFreqMHz > 0:
+		return fmt.Sprintf("Clock  %s max", ghz(cpu.MaxFreqMHz))
+	}
+	return ""
+}
+
+// formatCPUTimes shows user, system and idle time, plus iowait, interrupts
+// and steal when the platform reports any.
+func formatCPUTimes(t CPUTimes) string {
+	if t == (CPUTimes{}) {
+		return ""
+	}
+	parts := []string{
+		fmt.Sprintf("user %.1f%%", t.User),
+		fmt.Sprintf("sys %.1f%%", t.System),
+	}
+	if t.IOWait > 0 {
+		parts = append(parts, fmt.Sprintf("iowait %.1f%%", t.IOWait))
+	}
+	if t.IRQ > 0 {
+		parts = append(parts, fmt.Sprintf("irq %.1f%%", t.IRQ))
+	}
+	if t.Steal > 0 {
+		parts = append(parts, fmt.Sprintf("steal %.1f%%", t.Steal))
+	}
+	parts = append(parts, fmt.Sprintf("idle %.1f%%", t.Idle))
+	return "Time   " + strings.Join(parts, "  ")
+}
+
+// coreGridLines lays out a sparkline per core in as many columns as the
+// rows allow. Cores that still don't fit are counted on the last line.
+func coreGridLines(cpu CPUStatus, width, rows int) []string {
+	const (
+		labelWidth = 7 // "Core12 "
+		valueWidth = 7 // " 100.0%"
+		gapWidth   = 3
+		minSpark   = 8
+	)
+	n := len(cpu.PerCore)
+	if n == 0 || rows < 1 {
+		return nil
+	}
+	maxCols := max(width/(labelWidth+minSpark+valueWidth+gapWidth), 1)
+	cols := min((n+rows-1)/rows, maxCols)
+	perCol := (n + cols - 1) / cols
+	more := 0
+	if perCol > rows {
+		perCol = rows - 1
+		more = n - perCol*cols
+	}
+	cellWidth := width / cols
+	sparkWidth := min(max(cellWidth-labelWidth-valueWidth-gapWidth, minSpark), cpuHistorySize)
+
+	var lines []string
+	for row := range perCol {
+		var b strings.Builder
+		for col := range cols {
+			i := col*perCol + row
+			if i >= n-more {
+				break
+			}
+			v := cpu.PerCore[i]
+			var history []float64
+			if i < len(cpu.PerCoreHistory) {
+				history = cpu.PerCoreHistory[i]
+			}
+			cell := fmt.Sprintf("Core%-2d %s %s", i+1,
+				severityStyle(percentLevel(v)).Render(sparkBlocksScaled(history, sparkWidth, 100)),
+				colorizePercent(v, fmt.Sprintf("%5.1f%%", v)))
+			b.WriteString(cell)
+			if col < cols-1 {
+				b.WriteString(strings.Repeat(" ", max(cellWidth-lipgloss.Width(cell), 1)))
+			}
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	if more > 0 {
+		lines = append(lines, subtleStyle.Render(fmt.Sprintf("+%d more cores", more)))
+	}
+	return lines
+}
+
+func renderMemoryCard(mem MemoryStatus, cg CgroupStatus) cardData {
 	// Check if swap is being used (or at least allocated).
 	hasSwap := mem.SwapTotal > 0 || mem.SwapUsed > 0
 
@@ -261,6 +433,18 @@ func renderMemoryCard(mem MemoryStatus) cardData {
 	freePercent := 100 - mem.UsedPercent
 	lines = append(lines, fmt.Sprintf("Free   %s  %5.1f%%", progressBar(freePercent), freePercent))
 
+	// Effective limit when confined by a cgroup memory limit.
+	if cg.MemoryLimit > 0 && (mem.Total == 0 || cg.MemoryLimit < mem.Total) {
+		limitPercent := float64(cg.MemoryUsed) / float64(cg.MemoryLimit) * 100
+		lines = append(lines, fmt.Sprintf("Limit  %s  %5.1f%% %s/%s", progressBar(limitPercent), limitPercent,
+			humanBytesCompact(cg.MemoryUsed), humanBytesCompact(cg.MemoryLimit)))
+	}
+
+	if len(mem.Breakdown) > 0 && mem.Total > 0 {
+		lines = append(lines, "Kinds  "+stackedBar(mem.Breakdown, mem.Total))
+		lines = append(lines, memoryLegend(mem.Breakdown)...)
+	}
+
 	if hasSwap {
 		// Layout with Swap:
 		// 3. Swap (progress bar + text)
@@ -293,10 +477,23 @@ func renderMemoryCard(mem MemoryStatus) cardData {
 		available := mem.Total - mem.Used
 		lines = append(lines, fmt.Sprintf("Avail  %s", humanBytes(available)))
 	}
+	if mem.SwapInRate > 0 || mem.SwapOutRate > 0 {
+		line := fmt.Sprintf("Paging in %s · out %s", formatRate(mem.SwapInRate), formatRate(mem.SwapOutRate))
+		if mem.SwapOutRate > 0 {
+			line = warnStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if mem.PageFaultRate > 0 {
+		lines = append(lines, fmt.Sprintf("Faults %s/s · %s major", formatPacketRate(mem.PageFaultRate), formatPacketRate(mem.MajorFaultRate)))
+	}
 	// Memory pressure status.
 	if mem.Pressure != "" {
 		pressureStyle := okStyle
 		pressureText := "Status " + mem.Pressure
+		if mem.Stall > 0 {
+			pressureText += fmt.Sprintf(" · %.1f%% stalled", mem.Stall)
+		}
 		switch mem.Pressure {
 		case "warn":
 			pressureStyle = warnStyle
@@ -305,11 +502,78 @@ func renderMemoryCard(mem MemoryStatus) cardData {
 		}
 		lines = append(lines, pressureStyle.Render(pressureText))
 	}
+	for i, p := range mem.Top {
+		label := "       "
+		if i == 0 {
+			label = "Top    "
+		}
+		lines = append(lines, fmt.Sprintf("%s%-18s %8s", label, shorten(p.Name, 18), humanBytesCompact(p.RSS)))
+	}
 	return cardData{icon: iconMemory, title: "Memory", lines: lines}
 }
 
+// memorySegmentRoles color the memory kinds in order; mono themes tell them
+// apart by glyph instead.
+var (
+	memorySegmentRoles  = []theme.Role{theme.Info, theme.Highlight, theme.Primary, theme.Warn}
+	memorySegmentGlyphs = []string{"█", "▓", "▒", "▚"}
+)
+
+func memorySegmentGlyph(i int) string {
+	glyph := "█"
+	if activeTheme.IsMono() {
+		glyph = memorySegmentGlyphs[i%len(memorySegmentGlyphs)]
+	}
+	return activeTheme.Style(memorySegmentRoles[i%len(memorySegmentRoles)]).Render(glyph)
+}
+
+// stackedBar draws each segment's share of total across a progress bar's
+// width; the rest is free memory.
+func stackedBar(segments []MemorySegment, total uint64) string {
+	const width = 16
+	var (
+		b    strings.Builder
+		sum  uint64
+		done int
+	)
+	for i, seg := range segments {
+		sum += seg.Bytes
+		// Round the running total so the cells always add up.
+		end := min(int(math.Round(float64(sum)/float64(total)*width)), width)
+		b.WriteString(strings.Repeat(memorySegmentGlyph(i), max(end-done, 0)))
+		done = max(done, end)
+	}
+	b.WriteString(subtleStyle.Render(strings.Repeat("░", width-done)))
+	return b.String()
+}
+
+// memoryLegend names the stacked bar's segments, two to a line.
+func memoryLegend(segments []MemorySegment) []string {
+	var lines []string
+	for i := 0; i < len(segments); i += 2 {
+		var parts []string
+		for j := i; j < min(i+2, len(segments)); j++ {
+			parts = append(parts, fmt.Sprintf("%s %-10s %5s", memorySegmentGlyph(j), segments[j].Name, humanBytesShort(segments[j].Bytes)))
+		}
+		lines = append(lines, "       "+strings.Join(parts, " "))
+	}
+	return lines
+}
+
+// formatStall renders a PSI stall percentage line.
+func formatStall(percent float64) string {
+	return fmt.Sprintf("Stall  %s  %5.1f%%", progressBar(percent), percent)
+}
+
+// diskCardLimit is how many volumes the dashboard card shows; the disk view
+// lists the rest.
+const diskCardLimit = 3
+
 func renderDiskCard(disks []DiskStatus, io DiskIOStatus) cardData {
 	var lines []string
+	if len(disks) > diskCardLimit {
+		disks = disks[:diskCardLimit]
+	}
 	if len(disks) == 0 {
 		lines = append(lines, subtleStyle.Render("Collecting..."))
 	} else {
@@ -331,8 +595,11 @@ func renderDiskCard(disks []DiskStatus, io DiskIOStatus) cardData {
 	}
 	readBar := ioBar(io.ReadRate)
 	writeBar := ioBar(io.WriteRate)
-	lines = append(lines, fmt.Sprintf("Read   %s  %.1f MB/s", readBar, io.ReadRate))
-	lines = append(lines, fmt.Sprintf("Write  %s  %.1f MB/s", writeBar, io.WriteRate))
+	lines = append(lines, fmt.Sprintf("Read   %s  %s", readBar, formatRate(io.ReadRate)))
+	lines = append(lines, fmt.Sprintf("Write  %s  %s", writeBar, formatRate(io.WriteRate)))
+	if io.Stall > 0 {
+		lines = append(lines, formatStall(io.Stall))
+	}
 	return cardData{icon: iconDisk, title: "Disk", lines: lines}
 }
 
@@ -379,9 +646,121 @@ func ioBar(rate float64) string {
 	return okStyle.Render(bar)
 }
 
-func renderProcessCard(procs []ProcessInfo) cardData {
+func renderGPUCard(gpus []GPUStatus) cardData {
+	var lines []string
+	for i, g := range gpus {
+		if i >= 2 {
+			break
+		}
+		lines = append(lines, subtleStyle.Render(shorten(g.Name, 30)))
+		if g.Usage >= 0 {
+			lines = append(lines, fmt.Sprintf("Usage  %s  %5.1f%%", progressBar(g.Usage), g.Usage))
+		}
+		if g.MemoryTotal > 0 {
+			memPercent := g.MemoryUsed / g.MemoryTotal * 100
+			lines = append(lines, fmt.Sprintf("VRAM   %s  %5.1f%% %s/%s", progressBar(memPercent), memPercent,
+				humanBytesCompact(uint64(g.MemoryUsed)<<20), humanBytesCompact(uint64(g.MemoryTotal)<<20)))
+		}
+		var parts []string
+		if g.FreqMHz > 0 {
+			clock := fmt.Sprintf("%d MHz", g.FreqMHz)
+			if g.MaxFreqMHz > 0 {
+				clock = fmt.Sprintf("%d/%d MHz", g.FreqMHz, g.MaxFreqMHz)
+			}
+			parts = append(parts, clock)
+		}
+		if g.Temp > 0 {
+			parts = append(parts, colorizeTemp(g.Temp))
+		}
+		if len(parts) > 0 {
+			lines = append(lines, "Clock  "+strings.Join(parts, " · "))
+		}
+	}
+	return cardData{icon: iconGPU, title: "GPU", lines: lines}
+}
+
+// hasGPUData reports whether any GPU has live metrics beyond its name, so
+// platforms that only know the model don't get an empty card.
+func hasGPUData(gpus []GPUStatus) bool {
+	for _, g := range gpus {
+		if g.MemoryTotal > 0 || g.FreqMHz > 0 || g.Temp > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func renderDriveHealthCard(drives []DriveHealth) cardData {
+	var lines []string
+	for _, d := range drives {
+		dot := okStyle.Render("●")
+		switch d.Level() {
+		case "warn":
+			dot = warnStyle.Render("●")
+		case "critical":
+			dot = dangerStyle.Render("●")
+		}
+		var parts []string
+		if d.Temp > 0 {
+			parts = append(parts, colorizeTemp(d.Temp))
+		}
+		if d.PercentUsed >= 0 {
+			parts = append(parts, fmt.Sprintf("%d%% worn", d.PercentUsed))
+		}
+		if d.PowerOnHours > 0 {
+			parts = append(parts, formatPowerOnHours(d.PowerOnHours))
+		}
+		lines = append(lines, fmt.Sprintf("%s %-8s %s", dot, shorten(filepath.Base(d.Device), 8), strings.Join(parts, " · ")))
+
+		var problems []string
+		if d.Failed {
+			problems = append(problems, "SMART failed")
+		}
+		if d.MediaErrors > 0 {
+			problems = append(problems, fmt.Sprintf("%d media errors", d.MediaErrors))
+		}
+		if d.Reallocated > 0 {
+			problems = append(problems, fmt.Sprintf("%d reallocated", d.Reallocated))
+		}
+		if len(problems) > 0 {
+			style := warnStyle
+			if d.Level() == "critical" {
+				style = dangerStyle
+			}
+			lines = append(lines, "  "+style.Render(strings.Join(problems, " · ")))
+		}
+	}
+	return cardData{icon: iconDisk, title: "Drives", lines: lines}
+}
+
+func formatPowerOnHours(hours uint64) string {
+	if hours < 1000 {
+		return fmt.Sprintf("%dh on", hours)
+	}
+	return fmt.Sprintf("%.1fk h on", float64(hours)/1000)
+}
+
+func renderCgroupCard(cg CgroupStatus) cardData {
+	row := func(name string, cores float64, mem uint64) string {
+		return fmt.Sprintf("%-16s  %5.1f%%  %7s", shorten(name, 16), cores*100, humanBytesCompact(mem))
+	}
+	lines := []string{row(cg.Path, cg.CPUUsage, cg.MemoryUsed)}
+	for _, child := range cg.Children {
+		// Unit names are unique, so the slice they sit in can go.
+		lines = append(lines, row(filepath.Base(child.Name), child.CPUUsage, child.MemoryUsed))
+	}
+	if len(cg.Children) == 0 && cg.Version == 1 {
+		lines = append(lines, subtleStyle.Render("Breakdown needs cgroup v2"))
+	}
+	lines = append(lines, fmt.Sprintf("IO     R %s · W %s %s", formatRateCompact(cg.ReadRate), formatRateCompact(cg.WriteRate), unitFmt.RateUnit()))
+	return cardData{icon: iconProcs, title: "Cgroups", lines: lines}
+}
+
+// processCardRows is how many processes the dashboard card lists.
+const processCardRows = 3
+
+func renderProcessCard(procs []ProcessInfo, maxProcs int) cardData {
 	var lines []string
-	maxProcs := 3
 	for i, p := range procs {
 		if i >= maxProcs {
 			break
@@ -396,20 +775,94 @@ func renderProcessCard(procs []ProcessInfo) cardData {
 	return cardData{icon: iconProcs, title: "Processes", lines: lines}
 }
 
-func buildCards(m MetricsSnapshot, width int) []cardData {
-	cards := []cardData{
-		renderCPUCard(m.CPU, m.Thermal),
-		renderMemoryCard(m.Memory),
-		renderDiskCard(m.Disks, m.DiskIO),
-		renderBatteryCard(m.Batteries, m.Thermal),
-		renderProcessCard(m.TopProcesses),
-		renderNetworkCard(m.Network, m.NetworkHistory, m.Proxy, width),
+func renderTopIOCard(procs []ProcessInfo) cardData {
+	var lines []string
+	for _, p := range procs {
+		disk := p.ReadRate + p.WriteRate
+		sock := p.NetRxRate + p.NetTxRate
+		lines = append(lines, fmt.Sprintf("%-12s  %s  Disk %s · Net %s",
+			shorten(p.Name, 12), ioBar(disk+sock), formatRateCompact(disk), formatRateCompact(sock)))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, subtleStyle.Render("No IO activity"))
+	}
+	return cardData{icon: iconIO, title: "Top IO", lines: lines}
+}
+
+// leakCardRows is how many suspected leaks the leaks card lists.
+const leakCardRows = 4
+
+func renderLeakCard(leaks []LeakSuspect, now time.Time) cardData {
+	var lines []string
+	for i, l := range leaks {
+		if i == leakCardRows {
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("+%d more growing", len(leaks)-i)))
+			break
+		}
+		perMinute := uint64(l.Rate * 60 * 1024 * 1024)
+		lines = append(lines, fmt.Sprintf("%-14s %6s  %s/min for %s",
+			shorten(l.Name, 14), humanBytesCompact(l.RSS), warnStyle.Render("+"+humanBytesCompact(perMinute)),
+			formatUptime(uint64(now.Sub(l.Since).Seconds()))))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, subtleStyle.Render("No steady growth"))
+	}
+	return cardData{icon: iconLeaks, title: "Suspected Leaks", lines: lines}
+}
+
+// listenCardRows is how many listening sockets the connections card lists.
+const listenCardRows = 4
+
+func renderConnectionsCard(conns ConnectionStatus, listenRows int) cardData {
+	var lines []string
+	for i, l := range conns.Listening {
+		if i == listenRows {
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("+%d more listening", len(conns.Listening)-i)))
+			break
+		}
+		owner := l.Process
+		if owner == "" {
+			owner = "-"
+		}
+		if l.PID > 0 {
+			owner = fmt.Sprintf("%s (%d)", owner, l.PID)
+		}
+		lines = append(lines, fmt.Sprintf("%-3s %-16s %s", l.Proto, shorten(fmt.Sprintf("%s:%d", l.Address, l.Port), 16), shorten(owner, 24)))
+	}
+	if len(conns.Listening) == 0 {
+		lines = append(lines, subtleStyle.Render("Nothing listening"))
+	}
+
+	summary := "Estab  " + unitFmt.Count(int64(conns.Established))
+	if conns.TimeWait > 0 {
+		summary += " · " + unitFmt.Count(int64(conns.TimeWait)) + " time-wait"
+	}
+	if conns.CloseWait > 0 {
+		summary += " · " + warnStyle.Render(unitFmt.Count(int64(conns.CloseWait))+" close-wait")
+	}
+	lines = append(lines, summary)
+	if line := formatConnectionCounts("Procs ", conns.ByProcess); line != "" {
+		lines = append(lines, line)
+	}
+	if line := formatConnectionCounts("Peers ", conns.ByRemote); line != "" {
+		lines = append(lines, line)
 	}
-	// Sensors card disabled - redundant with CPU temp
-	// if hasSensorData(m.Sensors) {
-	// 	cards = append(cards, renderSensorsCard(m.Sensors))
-	// }
-	return cards
+	return cardData{icon: iconConns, title: "Connections", lines: lines}
+}
+
+// formatConnectionCounts lists the top three as "name ×n".
+func formatConnectionCounts(label string, counts []ConnectionCount) string {
+	var parts []string
+	for i, c := range counts {
+		if i == 3 {
+			break
+		}
+		parts = append(parts, shorten(c.Name, 15)+" ×"+unitFmt.Count(int64(c.Count)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return label + " " + strings.Join(parts, " · ")
 }
 
 func miniBar(percent float64) string {
@@ -420,7 +873,7 @@ func miniBar(percent float64) string {
 	return colorizePercent(percent, strings.Repeat("▮", filled)+strings.Repeat("▯", 5-filled))
 }
 
-func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy ProxyStatus, cardWidth int) cardData {
+func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy ProxyStatus, probes []ProbeStatus, cardWidth int) cardData {
 	var lines []string
 	var totalRx, totalTx float64
 	var primaryIP string
@@ -428,7 +881,7 @@ func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy P
 	for _, n := range netStats {
 		totalRx += n.RxRateMBs
 		totalTx += n.TxRateMBs
-		if primaryIP == "" && n.IP != "" && n.Name == "en0" {
+		if primaryIP == "" && n.IP != "" && (n.Name == "en0" || len(netStats) == 1) {
 			primaryIP = n.IP
 		}
 	}
@@ -451,8 +904,16 @@ func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy P
 		// sparkline graphs
 		rxSparkline := sparkline(history.RxHistory, totalRx, graphWidth)
 		txSparkline := sparkline(history.TxHistory, totalTx, graphWidth)
-		lines = append(lines, fmt.Sprintf("Down   %s  %s", rxSparkline, formatRate(totalRx)))
-		lines = append(lines, fmt.Sprintf("Up     %s  %s", txSparkline, formatRate(totalTx)))
+		lines = append(lines, fmt.Sprintf("Down   %s  %s", rxSparkline, formatNetRate(totalRx)))
+		lines = append(lines, fmt.Sprintf("Up     %s  %s", txSparkline, formatNetRate(totalTx)))
+		if len(netStats) == 1 {
+			n := netStats[0]
+			line := fmt.Sprintf("%-6s %s/%s pkt/s", shorten(n.Name, 6), formatPacketRate(n.RxPackets), formatPacketRate(n.TxPackets))
+			if n.Errors > 0 || n.Drops > 0 {
+				line += "  " + warnStyle.Render(fmt.Sprintf("%d errs · %d drops", n.Errors, n.Drops))
+			}
+			lines = append(lines, line)
+		}
 		// Show proxy and IP on one line.
 		var infoParts []string
 		if proxy.Enabled {
@@ -465,11 +926,127 @@ func renderNetworkCard(netStats []NetworkStatus, history NetworkHistory, proxy P
 			lines = append(lines, strings.Join(infoParts, " · "))
 		}
 	}
+	for _, p := range probes {
+		lines = append(lines, formatProbeLine(p, 8))
+	}
+	return cardData{icon: iconNetwork, title: "Network", lines: lines}
+}
+
+// probeWarnLatency marks a probe slow; any failures in its history warn too.
+const probeWarnLatency = 200.0 // ms
+
+func formatProbeLine(p ProbeStatus, sparkWidth int) string {
+	name := shorten(strings.TrimPrefix(strings.TrimPrefix(p.Name, "tcp://"), "dns://"), 16)
+	if !p.Up {
+		return fmt.Sprintf("%s %-16s %s", dangerStyle.Render("✕"), name, dangerStyle.Render(fmt.Sprintf("down · %.0f%% failed", p.FailureRate)))
+	}
+	style := okStyle
+	if p.FailureRate > 0 || p.Latency > probeWarnLatency {
+		style = warnStyle
+	}
+	stats := fmt.Sprintf("%s ±%.0f", formatLatency(p.Latency), p.Jitter)
+	if p.FailureRate > 0 {
+		stats += fmt.Sprintf(" · %.0f%% failed", p.FailureRate)
+	}
+	return fmt.Sprintf("%s %-16s %s  %s", style.Render("✓"), name, style.Render(sparkBlocks(p.History, sparkWidth)), stats)
+}
+
+// renderNetworkZoom is the full-screen network card: full-width history,
+// every interface's counters and each probe's whole history.
+func renderNetworkZoom(netStats []NetworkStatus, history NetworkHistory, proxy ProxyStatus, probes []ProbeStatus, width int) cardData {
+	var totalRx, totalTx float64
+	for _, n := range netStats {
+		totalRx += n.RxRateMBs
+		totalTx += n.TxRateMBs
+	}
+	graphWidth := max(width-24, 5)
+	lines := []string{
+		fmt.Sprintf("Down   %s  %s", sparkline(history.RxHistory, totalRx, graphWidth), formatNetRate(totalRx)),
+		fmt.Sprintf("Up     %s  %s", sparkline(history.TxHistory, totalTx, graphWidth), formatNetRate(totalTx)),
+	}
+	if proxy.Enabled {
+		lines = append(lines, fmt.Sprintf("Proxy  %s %s", proxy.Type, proxy.Host))
+	}
+
+	lines = append(lines, "", subtleStyle.Render(fmt.Sprintf("%-10s %-15s %10s %10s %13s %8s %8s",
+		"IFACE", "IP", "DOWN", "UP", "PKT/S", "ERRS", "DROPS")))
+	for _, n := range netStats {
+		line := fmt.Sprintf("%-10s %-15s %10s %10s %13s %8d %8d",
+			shorten(n.Name, 10), n.IP, formatNetRate(n.RxRateMBs), formatNetRate(n.TxRateMBs),
+			formatPacketRate(n.RxPackets)+"/"+formatPacketRate(n.TxPackets), n.Errors, n.Drops)
+		if n.Errors > 0 || n.Drops > 0 {
+			line = warnStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	if len(probes) > 0 {
+		lines = append(lines, "")
+		for _, p := range probes {
+			lines = append(lines, formatProbeLine(p, probeHistorySize))
+		}
+	}
 	return cardData{icon: iconNetwork, title: "Network", lines: lines}
 }
 
+// focusNetwork narrows the snapshot's network data to one interface so the
+// card shows its sparkline and counters. Unknown names leave it unchanged.
+func focusNetwork(snap MetricsSnapshot, name string) MetricsSnapshot {
+	for _, n := range snap.Network {
+		if n.Name == name {
+			snap.Network = []NetworkStatus{n}
+			snap.NetworkHistory = n.History
+			return snap
+		}
+	}
+	return snap
+}
+
+// nextInterface cycles all interfaces → each interface by name → all.
+func nextInterface(netStats []NetworkStatus, current string) string {
+	names := make([]string, 0, len(netStats))
+	for _, n := range netStats {
+		names = append(names, n.Name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if current == "" || name > current {
+			return name
+		}
+	}
+	return ""
+}
+
+func formatPacketRate(pps float64) string {
+	if pps < 1000 {
+		return fmt.Sprintf("%.0f", pps)
+	}
+	return fmt.Sprintf("%.1fk", pps/1000)
+}
+
 // 8 levels: ▁▂▃▄▅▆▇█
 func sparkline(history []float64, current float64, width int) string {
+	result := sparkBlocks(history, width)
+	if current > 8 {
+		return dangerStyle.Render(result)
+	}
+	if current > 3 {
+		return warnStyle.Render(result)
+	}
+	return okStyle.Render(result)
+}
+
+// sparkBlocks draws the latest width points scaled to their maximum.
+func sparkBlocks(history []float64, width int) string {
+	maxVal := 0.1
+	for _, v := range history[max(len(history)-width, 0):] {
+		maxVal = max(maxVal, v)
+	}
+	return sparkBlocksScaled(history, width, maxVal)
+}
+
+// sparkBlocksScaled draws the latest width points with maxVal as a full block.
+func sparkBlocksScaled(history []float64, width int, maxVal float64) string {
 	blocks := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 
 	data := make([]float64, 0, width)
@@ -489,13 +1066,6 @@ func sparkline(history []float64, current float64, width int) string {
 		data = data[len(data)-width:]
 	}
 
-	maxVal := 0.1
-	for _, v := range data {
-		if v > maxVal {
-			maxVal = v
-		}
-	}
-
 	var builder strings.Builder
 	for _, v := range data {
 		level := int((v / maxVal) * float64(len(blocks)-1))
@@ -507,15 +1077,40 @@ func sparkline(history []float64, current float64, width int) string {
 		}
 		builder.WriteRune(blocks[level])
 	}
+	return builder.String()
+}
 
-	result := builder.String()
-	if current > 8 {
-		return dangerStyle.Render(result)
-	}
-	if current > 3 {
-		return warnStyle.Render(result)
+// renderSensorsCard lists the hottest temperatures followed by fans and power.
+func renderSensorsCard(readings []SensorReading) cardData {
+	const maxSensors = 6
+	sorted := make([]SensorReading, len(readings))
+	copy(sorted, readings)
+	unitOrder := map[string]int{unitCelsius: 0, unitRPM: 1, unitWatts: 2}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Unit != sorted[j].Unit {
+			return unitOrder[sorted[i].Unit] < unitOrder[sorted[j].Unit]
+		}
+		return sorted[i].Unit == unitCelsius && sorted[i].Value > sorted[j].Value
+	})
+
+	var lines []string
+	for i, r := range sorted {
+		if i >= maxSensors {
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("+%d more", len(sorted)-maxSensors)))
+			break
+		}
+		var value string
+		switch r.Unit {
+		case unitCelsius:
+			value = colorizeTemp(r.Value)
+		case unitRPM:
+			value = fmt.Sprintf("%.0f %s", r.Value, unitRPM)
+		default:
+			value = fmt.Sprintf("%.1f%s", r.Value, r.Unit)
+		}
+		lines = append(lines, fmt.Sprintf("%-22s  %s", shorten(r.Label, 22), value))
 	}
-	return okStyle.Render(result)
+	return cardData{icon: iconSensors, title: "Sensors", lines: lines}
 }
 
 func renderBatteryCard(batts []BatteryStatus, thermal ThermalStatus) cardData {
@@ -563,6 +1158,9 @@ func renderBatteryCard(batts []BatteryStatus, thermal ThermalStatus) cardData {
 				statusText += fmt.Sprintf(" · %.0fW", thermal.SystemPower)
 			} else if thermal.AdapterPower > 0 {
 				statusText += fmt.Sprintf(" · %.0fW Adapter", thermal.AdapterPower)
+			} else if thermal.BatteryPower < 0 {
+				// Linux reports the charge rate rather than system draw.
+				statusText += fmt.Sprintf(" · %.0fW", -thermal.BatteryPower)
 			}
 		} else if thermal.BatteryPower > 0 {
 			// Only show battery power when discharging (positive value)
@@ -579,7 +1177,7 @@ func renderBatteryCard(batts []BatteryStatus, thermal ThermalStatus) cardData {
 		}
 
 		if thermal.CPUTemp > 0 {
-			tempText := colorizeTemp(thermal.CPUTemp) + "°C" // Reuse common color logic
+			tempText := colorizeTemp(thermal.CPUTemp) // Reuse common color logic
 			healthParts = append(healthParts, tempText)
 		}
 
@@ -597,8 +1195,15 @@ func renderBatteryCard(batts []BatteryStatus, thermal ThermalStatus) cardData {
 
 func renderCard(data cardData, width int, height int) string {
 	titleText := data.icon + " " + data.title
-	lineLen := max(width-lipgloss.Width(titleText)-2, 4)
-	header := titleStyle.Render(titleText) + "  " + lineStyle.Render(strings.Repeat("╌", lineLen))
+	header := titleStyle.Render(titleText)
+	if data.focused {
+		header = selectedStyle.Render(" " + titleText + " ")
+	}
+	if data.badge != "" {
+		header += " " + data.badge
+	}
+	lineLen := max(width-lipgloss.Width(header)-2, 4)
+	header += "  " + lineStyle.Render(strings.Repeat("╌", lineLen))
 	content := header + "\n" + strings.Join(data.lines, "\n")
 
 	lines := strings.Split(content, "\n")
@@ -650,95 +1255,104 @@ func batteryProgressBar(percent float64) string {
 	return colorizeBattery(percent, builder.String())
 }
 
-func colorizePercent(percent float64, s string) string {
+// percentLevel grades usage: 60% warns, 85% is critical.
+func percentLevel(percent float64) theme.Level {
 	switch {
 	case percent >= 85:
-		return dangerStyle.Render(s)
+		return theme.LevelDanger
 	case percent >= 60:
-		return warnStyle.Render(s)
+		return theme.LevelWarn
+	default:
+		return theme.LevelOK
+	}
+}
+
+func severityStyle(level theme.Level) lipgloss.Style {
+	switch level {
+	case theme.LevelDanger:
+		return dangerStyle
+	case theme.LevelWarn:
+		return warnStyle
 	default:
-		return okStyle.Render(s)
+		return okStyle
 	}
 }
 
+// paint colors s by severity. Monochrome themes prefix a symbol instead,
+// or a space when there is none so marked and unmarked values line up.
+func paint(level theme.Level, s string) string {
+	mark := activeTheme.Mark(level)
+	if mark == "" && activeTheme.IsMono() {
+		mark = " "
+	}
+	return mark + severityStyle(level).Render(s)
+}
+
+// paintLine is paint for table rows: the symbol goes at the end so the
+// columns stay aligned with their header.
+func paintLine(level theme.Level, line string) string {
+	line = severityStyle(level).Render(line)
+	if mark := activeTheme.Mark(level); mark != "" {
+		return line + " " + mark
+	}
+	return line
+}
+
+func colorizePercent(percent float64, s string) string {
+	return paint(percentLevel(percent), s)
+}
+
 func colorizeBattery(percent float64, s string) string {
 	switch {
 	case percent < 20:
-		return dangerStyle.Render(s)
+		return paint(theme.LevelDanger, s)
 	case percent < 50:
-		return warnStyle.Render(s)
+		return paint(theme.LevelWarn, s)
 	default:
-		return okStyle.Render(s)
+		return paint(theme.LevelOK, s)
 	}
 }
 
 func colorizeTemp(t float64) string {
+	s := unitFmt.Temp(t)
 	switch {
 	case t >= 76:
-		return dangerStyle.Render(fmt.Sprintf("%.1f", t))
+		return paint(theme.LevelDanger, s)
 	case t >= 56:
-		return warnStyle.Render(fmt.Sprintf("%.1f", t))
+		return paint(theme.LevelWarn, s)
 	default:
-		return okStyle.Render(fmt.Sprintf("%.1f", t))
+		return paint(theme.LevelOK, s)
 	}
 }
 
+// bytesPerMB converts the collectors' MiB/s figures back to bytes.
+const bytesPerMB = 1 << 20
+
+// formatRate formats a storage rate given in MiB/s.
 func formatRate(mb float64) string {
-	if mb < 0.01 {
-		return "0 MB/s"
-	}
-	if mb < 1 {
-		return fmt.Sprintf("%.2f MB/s", mb)
-	}
-	if mb < 10 {
-		return fmt.Sprintf("%.1f MB/s", mb)
-	}
-	return fmt.Sprintf("%.0f MB/s", mb)
+	return unitFmt.Rate(mb * bytesPerMB)
+}
+
+// formatNetRate is formatRate for network traffic, which may show bits.
+func formatNetRate(mb float64) string {
+	return unitFmt.NetRate(mb * bytesPerMB)
+}
+
+// formatRateCompact formats MiB/s without the unit suffix, for dense rows.
+func formatRateCompact(mb float64) string {
+	return unitFmt.RateCompact(mb * bytesPerMB)
 }
 
 func humanBytes(v uint64) string {
-	switch {
-	case v > 1<<40:
-		return fmt.Sprintf("%.1f TB", float64(v)/(1<<40))
-	case v > 1<<30:
-		return fmt.Sprintf("%.1f GB", float64(v)/(1<<30))
-	case v > 1<<20:
-		return fmt.Sprintf("%.1f MB", float64(v)/(1<<20))
-	case v > 1<<10:
-		return fmt.Sprintf("%.1f KB", float64(v)/(1<<10))
-	default:
-		return strconv.FormatUint(v, 10) + " B"
-	}
+	return unitFmt.Bytes(v)
 }
 
 func humanBytesShort(v uint64) string {
-	switch {
-	case v >= 1<<40:
-		return fmt.Sprintf("%.0fT", float64(v)/(1<<40))
-	case v >= 1<<30:
-		return fmt.Sprintf("%.0fG", float64(v)/(1<<30))
-	case v >= 1<<20:
-		return fmt.Sprintf("%.0fM", float64(v)/(1<<20))
-	case v >= 1<<10:
-		return fmt.Sprintf("%.0fK", float64(v)/(1<<10))
-	default:
-		return strconv.FormatUint(v, 10)
-	}
+	return unitFmt.BytesShort(v)
 }
 
 func humanBytesCompact(v uint64) string {
-	switch {
-	case v >= 1<<40:
-		return fmt.Sprintf("%.1fT", float64(v)/(1<<40))
-	case v >= 1<<30:
-		return fmt.Sprintf("%.1fG", float64(v)/(1<<30))
-	case v >= 1<<20:
-		return fmt.Sprintf("%.1fM", float64(v)/(1<<20))
-	case v >= 1<<10:
-		return fmt.Sprintf("%.1fK", float64(v)/(1<<10))
-	default:
-		return strconv.FormatUint(v, 10)
-	}
+	return unitFmt.BytesCompact(v)
 }
 
 func shorten(s string, maxLen int) string {
@@ -747,38 +1361,3 @@ func shorten(s string, maxLen int) string {
 	}
 	return s[:maxLen-1] + "…"
 }
-
-func renderTwoColumns(cards []cardData, width int) string {
-	if len(cards) == 0 {
-		return ""
-	}
-	cw := colWidth
-	if width > 0 && width/2-2 > cw {
-		cw = width/2 - 2
-	}
-	var rows []string
-	for i := 0; i < len(cards); i += 2 {
-		left := renderCard(cards[i], cw, 0)
-		right := ""
-		if i+1 < len(cards) {
-			right = renderCard(cards[i+1], cw, 0)
-		}
-		targetHeight := max(lipgloss.Height(left), lipgloss.Height(right))
-		left = renderCard(cards[i], cw, targetHeight)
-		if right != "" {
-			right = renderCard(cards[i+1], cw, targetHeight)
-			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, left, "  ", right))
-		} else {
-			rows = append(rows, left)
-		}
-	}
-
-	var spacedRows []string
-	for i, r := range rows {
-		if i > 0 {
-			spacedRows = append(spacedRows, "")
-		}
-		spacedRows = append(spacedRows, r)
-	}
-	return lipgloss.JoinVertical(lipgloss.Left, spacedRows...)
-}
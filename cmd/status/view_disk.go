@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderDiskPanel is the full-screen disk view: every volume with inode usage
+// and filesystem, then per-device throughput.
+func renderDiskPanel(disks []DiskStatus, io DiskIOStatus, width, height int) string {
+	if width <= 0 {
+		width = 80
+	}
+	titleText := iconDisk + " Disks"
+	info := fmt.Sprintf("%d volumes · %d devices", len(disks), len(io.Devices))
+	lineLen := max(width-lipgloss.Width(titleText)-lipgloss.Width(info)-6, 4)
+	lines := []string{titleStyle.Render(titleText) + "  " + lineStyle.Render(strings.Repeat("╌", lineLen)) + "  " + subtleStyle.Render(info)}
+	lines = append(lines, diskTableLines(disks, io, width, height-3)...)
+	lines = append(lines, "", subtleStyle.Render("d/esc back  p processes  q quit"))
+	return strings.Join(lines, "\n")
+}
+
+// diskTableLines lists volumes then devices, cutting devices short to fit
+// height rows.
+func diskTableLines(disks []DiskStatus, io DiskIOStatus, width, height int) []string {
+	var lines []string
+	// Fixed columns take 56 cells; the mount point gets the rest.
+	mountWidth := max(width-56, 12)
+	lines = append(lines, subtleStyle.Render(fmt.Sprintf("%-*s %-8s %7s %7s %6s %7s  %s",
+		mountWidth, "MOUNT", "FS", "USED", "SIZE", "USE%", "INODE%", "DEVICE")))
+	if len(disks) == 0 {
+		lines = append(lines, subtleStyle.Render("No disks detected"))
+	}
+	for _, d := range disks {
+		inodes := "-"
+		if d.InodesTotal > 0 {
+			inodes = fmt.Sprintf("%.1f", d.InodesPercent)
+		}
+		line := fmt.Sprintf("%-*s %-8s %7s %7s %6.1f %7s  %s",
+			mountWidth, shorten(d.Mount, mountWidth), shorten(d.Fstype, 8),
+			humanBytesShort(d.Used), humanBytesShort(d.Total), d.UsedPercent, inodes, d.Device)
+		lines = append(lines, paintLine(percentLevel(max(d.UsedPercent, d.InodesPercent)), line))
+	}
+
+	lines = append(lines, "", subtleStyle.Render(fmt.Sprintf("%-12s %10s %10s %8s %8s %8s",
+		"DEVICE", "READ", "WRITE", "R/s", "W/s", "LATENCY")))
+	if len(io.Devices) == 0 {
+		lines = append(lines, subtleStyle.Render("Collecting..."))
+	}
+	for i, dev := range io.Devices {
+		if len(lines) >= height-1 && height > 0 {
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("… %d more", len(io.Devices)-i)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%-12s %10s %10s %8.0f %8.0f %8s",
+			shorten(dev.Name, 12), formatRate(dev.ReadRate), formatRate(dev.WriteRate),
+			dev.ReadIOPS, dev.WriteIOPS, formatLatency(dev.Latency)))
+	}
+	return lines
+}
+
+func formatLatency(ms float64) string {
+	switch {
+	case ms <= 0:
+		return "-"
+	case ms < 10:
+		return fmt.Sprintf("%.2fms", ms)
+	default:
+		return fmt.Sprintf("%.0fms", ms)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testVolumes(n int) []DiskStatus {
+	var disks []DiskStatus
+	for i := range n {
+		disks = append(disks, DiskStatus{
+			Mount: fmt.Sprintf("/mnt/vol%d", i), Device: fmt.Sprintf("/dev/sd%c1", 'a'+i), Fstype: "ext4",
+			Used: 10 << 30, Total: uint64(100-i) << 30, UsedPercent: 10, InodesTotal: 1000, InodesUsed: 420, InodesPercent: 42,
+		})
+	}
+	return disks
+}
+
+func TestDiskCardShowsLargestVolumes(t *testing.T) {
+	card := renderDiskCard(testVolumes(5), DiskIOStatus{})
+	if got := len(card.lines); got != diskCardLimit+2 {
+		t.Errorf("card has %d lines, want %d volumes plus read/write:\n%s", got, diskCardLimit, strings.Join(card.lines, "\n"))
+	}
+}
+
+func TestDiskPanel(t *testing.T) {
+	io := DiskIOStatus{Devices: []DeviceIOStatus{
+		{Name: "sda", ReadRate: 2, WriteRate: 1, ReadIOPS: 100, WriteIOPS: 100, Latency: 4},
+		{Name: "sdb"},
+	}}
+	out := stripANSI(renderDiskPanel(testVolumes(5), io, 100, 40))
+	for _, want := range []string{"5 volumes · 2 devices", "/mnt/vol4", "ext4", "42.0", "/dev/sde1", "2.0 MiB/s", "4.00ms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("panel missing %q:\n%s", want, out)
+		}
+	}
+
+	// Devices beyond the screen are summarized.
+	out = stripANSI(renderDiskPanel(testVolumes(5), io, 100, 13))
+	if !strings.Contains(out, "… 1 more") {
+		t.Errorf("short panel:\n%s", out)
+	}
+}
+
+func TestDiskViewToggle(t *testing.T) {
+	m := model{collector: newTestCollector(), ready: true, width: 100, height: 40,
+		metrics: MetricsSnapshot{Disks: testVolumes(1)}}
+	key := func(m model, k string) model {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "esc" {
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		next, cmd := m.Update(msg)
+		if cmd != nil {
+			t.Fatalf("%s returned a command", k)
+		}
+		return next.(model)
+	}
+
+	m = key(m, "d")
+	if !m.diskView || !strings.Contains(stripANSI(m.View()), "INODE%") {
+		t.Fatal("d should open the disk view")
+	}
+	// esc closes the view instead of quitting.
+	if m = key(m, "esc"); m.diskView {
+		t.Error("esc should close the disk view")
+	}
+}
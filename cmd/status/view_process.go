@@ -0,0 +1,381 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+type processSortKey int
+
+const (
+	sortByCPU processSortKey = iota
+	sortByMemory
+	sortByIO
+	sortByThreads
+	sortByPID
+	sortByName
+	processSortKeyCount
+)
+
+var processSortNames = [...]string{"CPU", "Memory", "IO", "Threads", "PID", "Name"}
+
+func (k processSortKey) String() string {
+	if k < 0 || k >= processSortKeyCount {
+		return ""
+	}
+	return processSortNames[k]
+}
+
+// processRow is a process positioned in the panel, indented in tree view.
+type processRow struct {
+	ProcessInfo
+	depth int
+}
+
+// signalRequest is a pending SIGTERM/SIGKILL awaiting confirmation.
+type signalRequest struct {
+	pid  int32
+	name string
+	kill bool
+}
+
+type signalResultMsg struct {
+	req signalRequest
+	err error
+}
+
+// processPanel is the full-screen interactive process view.
+type processPanel struct {
+	open        bool
+	sortKey     processSortKey
+	ascending   bool
+	tree        bool
+	filter      string
+	filtering   bool
+	selectedPID int32
+	selectedRow int // Row selectedPID was last seen on
+	confirm     *signalRequest
+	message     string
+}
+
+// signalProcess delivers SIGTERM or SIGKILL; replaced in tests.
+var signalProcess = func(pid int32, kill bool) error {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return err
+	}
+	if kill {
+		return p.Kill()
+	}
+	return p.Terminate()
+}
+
+func lessProcess(a, b ProcessInfo, key processSortKey) bool {
+	switch key {
+	case sortByMemory:
+		if a.RSS != b.RSS {
+			return a.RSS > b.RSS
+		}
+	case sortByIO:
+		if ai, bi := a.totalIORate(), b.totalIORate(); ai != bi {
+			return ai > bi
+		}
+	case sortByThreads:
+		if a.Threads != b.Threads {
+			return a.Threads > b.Threads
+		}
+	case sortByPID:
+		return a.PID < b.PID
+	case sortByName:
+		an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name)
+		if an != bn {
+			return an < bn
+		}
+	default:
+		if a.CPU != b.CPU {
+			return a.CPU > b.CPU
+		}
+	}
+	return a.PID < b.PID
+}
+
+// sortProcesses orders processes by key; the natural order is descending for
+// metrics and ascending for PID and name, and ascending flips it.
+func sortProcesses(procs []ProcessInfo, key processSortKey, ascending bool) []ProcessInfo {
+	sorted := make([]ProcessInfo, len(procs))
+	copy(sorted, procs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ascending {
+			return lessProcess(sorted[j], sorted[i], key)
+		}
+		return lessProcess(sorted[i], sorted[j], key)
+	})
+	return sorted
+}
+
+// filterProcesses keeps processes whose name contains filter, ignoring case.
+func filterProcesses(procs []ProcessInfo, filter string) []ProcessInfo {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if filter == "" {
+		return procs
+	}
+	var out []ProcessInfo
+	for _, p := range procs {
+		if strings.Contains(strings.ToLower(p.Name), filter) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// buildProcessTree flattens processes into depth-first parent/child order.
+// Processes whose parent is not in the list become roots.
+func buildProcessTree(procs []ProcessInfo) []processRow {
+	present := make(map[int32]bool, len(procs))
+	for _, p := range procs {
+		present[p.PID] = true
+	}
+	children := make(map[int32][]ProcessInfo)
+	var roots []ProcessInfo
+	for _, p := range procs {
+		if p.PPID != p.PID && present[p.PPID] {
+			children[p.PPID] = append(children[p.PPID], p)
+		} else {
+			roots = append(roots, p)
+		}
+	}
+
+	rows := make([]processRow, 0, len(procs))
+	visited := make(map[int32]bool, len(procs))
+	var walk func(p ProcessInfo, depth int)
+	walk = func(p ProcessInfo, depth int) {
+		if visited[p.PID] {
+			return
+		}
+		visited[p.PID] = true
+		rows = append(rows, processRow{ProcessInfo: p, depth: depth})
+		for _, child := range children[p.PID] {
+			walk(child, depth+1)
+		}
+	}
+	for _, r := range roots {
+		walk(r, 0)
+	}
+	// Cycles (PID reuse races) leave nodes unreachable from any root.
+	for _, p := range procs {
+		walk(p, 0)
+	}
+	return rows
+}
+
+// rows returns the processes currently visible in the panel.
+func (p processPanel) rows(procs []ProcessInfo) []processRow {
+	sorted := sortProcesses(filterProcesses(procs, p.filter), p.sortKey, p.ascending)
+	if p.tree {
+		return buildProcessTree(sorted)
+	}
+	rows := make([]processRow, len(sorted))
+	for i, proc := range sorted {
+		rows[i] = processRow{ProcessInfo: proc}
+	}
+	return rows
+}
+
+// selectedIndex finds the selected process's row, or the row it was last
+// seen on, clamped to the list, once it is gone.
+func selectedIndex(rows []processRow, pid int32, last int) int {
+	for i, r := range rows {
+		if r.PID == pid {
+			return i
+		}
+	}
+	return max(0, min(last, len(rows)-1))
+}
+
+// follow keeps the selection across a refresh: the selected process's new
+// row is remembered, and once it exits the selection passes to the process
+// now on its row rather than jumping to the top.
+func (p processPanel) follow(procs []ProcessInfo) processPanel {
+	if p.selectedPID == 0 {
+		return p
+	}
+	rows := p.rows(procs)
+	if len(rows) == 0 {
+		return p
+	}
+	p.selectedRow = selectedIndex(rows, p.selectedPID, p.selectedRow)
+	p.selectedPID = rows[p.selectedRow].PID
+	return p
+}
+
+// update handles a key press while the panel is open.
+func (p processPanel) update(msg tea.KeyMsg, procs []ProcessInfo, visible int) (processPanel, tea.Cmd) {
+	key := msg.String()
+
+	if p.confirm != nil {
+		req := *p.confirm
+		p.confirm = nil
+		if key == "y" || key == "Y" {
+			return p, sendSignalCmd(req)
+		}
+		p.message = "Cancelled"
+		return p, nil
+	}
+
+	if p.filtering {
+		switch msg.Type {
+		case tea.KeyEnter:
+			p.filtering = false
+		case tea.KeyEsc:
+			p.filtering = false
+			p.filter = ""
+		case tea.KeyBackspace:
+			if r := []rune(p.filter); len(r) > 0 {
+				p.filter = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			p.filter += string(msg.Runes)
+		}
+		return p, nil
+	}
+
+	p.message = ""
+	rows := p.rows(procs)
+	idx := selectedIndex(rows, p.selectedPID, p.selectedRow)
+	move := func(to int) {
+		if len(rows) == 0 {
+			return
+		}
+		to = max(0, min(to, len(rows)-1))
+		p.selectedPID = rows[to].PID
+		p.selectedRow = to
+	}
+
+	switch key {
+	case "esc", "p":
+		if p.filter != "" && key == "esc" {
+			p.filter = ""
+			return p, nil
+		}
+		p.open = false
+	case "up", "k":
+		move(idx - 1)
+	case "down", "j":
+		move(idx + 1)
+	case "pgup":
+		move(idx - visible)
+	case "pgdown":
+		move(idx + visible)
+	case "home", "g":
+		move(0)
+	case "end", "G":
+		move(len(rows) - 1)
+	case "s":
+		p.sortKey = (p.sortKey + 1) % processSortKeyCount
+	case "S":
+		p.ascending = !p.ascending
+	case "t":
+		p.tree = !p.tree
+	case "/":
+		p.filtering = true
+	case "x", "X":
+		if len(rows) == 0 {
+			return p, nil
+		}
+		sel := rows[idx]
+		p.confirm = &signalRequest{pid: sel.PID, name: sel.Name, kill: key == "X"}
+	}
+	return p, nil
+}
+
+func sendSignalCmd(req signalRequest) tea.Cmd {
+	return func() tea.Msg {
+		return signalResultMsg{req: req, err: signalProcess(req.pid, req.kill)}
+	}
+}
+
+func signalName(kill bool) string {
+	if kill {
+		return "SIGKILL"
+	}
+	return "SIGTERM"
+}
+
+func renderProcessPanel(p processPanel, procs []ProcessInfo, width, height int) string {
+	if width <= 0 {
+		width = 80
+	}
+	rows := p.rows(procs)
+
+	titleText := iconProcs + " Processes"
+	info := fmt.Sprintf("%d shown · sort %s", len(rows), p.sortKey)
+	if p.ascending {
+		info += " ↑"
+	} else {
+		info += " ↓"
+	}
+	if p.tree {
+		info += " · tree"
+	}
+	if p.filter != "" || p.filtering {
+		info += " · filter " + p.filter
+		if p.filtering {
+			info += "_"
+		}
+	}
+	lineLen := max(width-lipgloss.Width(titleText)-lipgloss.Width(info)-6, 4)
+	lines := []string{titleStyle.Render(titleText) + "  " + lineStyle.Render(strings.Repeat("╌", lineLen)) + "  " + subtleStyle.Render(info)}
+
+	// Fixed columns take 78 cells; the name gets the rest.
+	nameWidth := max(width-78, 12)
+	lines = append(lines, subtleStyle.Render(fmt.Sprintf("%7s %-10s %6s %8s %5s %5s %9s %9s %9s  %s",
+		"PID", "USER", "CPU%", "RSS", "THR", "FILES", "READ", "WRITE", "NET", "NAME")))
+
+	visible := max(height-4, 3)
+	idx := selectedIndex(rows, p.selectedPID, p.selectedRow)
+	// Scroll just far enough to keep the selection on screen.
+	offset := max(idx-visible+1, 0)
+
+	if len(rows) == 0 {
+		lines = append(lines, subtleStyle.Render("No matching processes"))
+	}
+	for i := offset; i < len(rows) && i < offset+visible; i++ {
+		r := rows[i]
+		name := r.Name
+		if p.tree && r.depth > 0 {
+			name = strings.Repeat("  ", r.depth-1) + "└ " + name
+		}
+		line := fmt.Sprintf("%7d %-10s %6.1f %8s %5d %5s %9s %9s %9s  %s",
+			r.PID, shorten(r.User, 10), r.CPU, humanBytesCompact(r.RSS), r.Threads, formatCount(r.OpenFiles),
+			formatRate(r.ReadRate), formatRate(r.WriteRate), formatRate(r.NetRxRate+r.NetTxRate), shorten(name, nameWidth))
+		if i == idx {
+			line = selectedStyle.Render(line)
+		} else {
+			line = paintLine(percentLevel(r.CPU), line)
+		}
+		lines = append(lines, line)
+	}
+
+	switch {
+	case p.confirm != nil:
+		lines = append(lines, "", warnStyle.Render(fmt.Sprintf("Send %s to %s (%d)? y/N",
+			signalName(p.confirm.kill), p.confirm.name, p.confirm.pid)))
+	case p.message != "":
+		lines = append(lines, "", subtleStyle.Render(p.message))
+	default:
+		lines = append(lines, "", subtleStyle.Render("↑↓ select  s sort  S reverse  / filter  t tree  x term  X kill  esc back"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatCount(n int32) string {
+	if n <= 0 {
+		return "-"
+	}
+	return unitFmt.Count(int64(n))
+}
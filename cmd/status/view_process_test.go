@@ -0,0 +1,190 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func sampleProcesses() []ProcessInfo {
+	return []ProcessInfo{
+		{PID: 1, PPID: 0, Name: "launchd", CPU: 0.5, RSS: 10 << 20, Threads: 4},
+		{PID: 20, PPID: 1, Name: "Chrome", CPU: 42.0, RSS: 900 << 20, Threads: 30},
+		{PID: 21, PPID: 20, Name: "Chrome Helper", CPU: 12.0, RSS: 300 << 20, Threads: 12, ReadRate: 5},
+		{PID: 30, PPID: 1, Name: "node", CPU: 80.0, RSS: 200 << 20, Threads: 8},
+	}
+}
+
+func pids(rows []processRow) []int32 {
+	var out []int32
+	for _, r := range rows {
+		out = append(out, r.PID)
+	}
+	return out
+}
+
+func equalPIDs(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestProcessPanelSorting(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       processSortKey
+		ascending bool
+		want      []int32
+	}{
+		{"cpu descending", sortByCPU, false, []int32{30, 20, 21, 1}},
+		{"cpu ascending", sortByCPU, true, []int32{1, 21, 20, 30}},
+		{"memory", sortByMemory, false, []int32{20, 21, 30, 1}},
+		{"io", sortByIO, false, []int32{21, 1, 20, 30}},
+		{"pid", sortByPID, false, []int32{1, 20, 21, 30}},
+		{"name", sortByName, false, []int32{20, 21, 1, 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := processPanel{sortKey: tt.key, ascending: tt.ascending}
+			if got := pids(p.rows(sampleProcesses())); !equalPIDs(got, tt.want) {
+				t.Errorf("rows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessPanelFilter(t *testing.T) {
+	p := processPanel{filter: "chrome"}
+	if got := pids(p.rows(sampleProcesses())); !equalPIDs(got, []int32{20, 21}) {
+		t.Errorf("filtered rows = %v, want [20 21]", got)
+	}
+}
+
+func TestBuildProcessTree(t *testing.T) {
+	rows := buildProcessTree(sortProcesses(sampleProcesses(), sortByCPU, false))
+	want := []int32{1, 30, 20, 21}
+	if got := pids(rows); !equalPIDs(got, want) {
+		t.Fatalf("tree order = %v, want %v", got, want)
+	}
+	depths := map[int32]int{1: 0, 30: 1, 20: 1, 21: 2}
+	for _, r := range rows {
+		if r.depth != depths[r.PID] {
+			t.Errorf("depth of %d = %d, want %d", r.PID, r.depth, depths[r.PID])
+		}
+	}
+}
+
+func TestBuildProcessTreeOrphansBecomeRoots(t *testing.T) {
+	procs := []ProcessInfo{
+		{PID: 5, PPID: 999, Name: "orphan"},
+		{PID: 6, PPID: 5, Name: "child"},
+	}
+	rows := buildProcessTree(procs)
+	if len(rows) != 2 || rows[0].depth != 0 || rows[1].depth != 1 {
+		t.Errorf("unexpected tree rows: %+v", rows)
+	}
+}
+
+func TestProcessPanelSignalConfirmation(t *testing.T) {
+	var gotPID int32
+	var gotKill bool
+	orig := signalProcess
+	signalProcess = func(pid int32, kill bool) error {
+		gotPID, gotKill = pid, kill
+		return nil
+	}
+	defer func() { signalProcess = orig }()
+
+	procs := sampleProcesses()
+	p := processPanel{open: true}
+	p, _ = p.update(tea.KeyMsg{Type: tea.KeyDown}, procs, 10)
+	p, cmd := p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")}, procs, 10)
+	if cmd != nil {
+		t.Fatal("signal sent before confirmation")
+	}
+	if p.confirm == nil || p.confirm.pid != 20 || !p.confirm.kill {
+		t.Fatalf("confirm = %+v, want SIGKILL for pid 20", p.confirm)
+	}
+
+	p, cmd = p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, procs, 10)
+	if cmd == nil {
+		t.Fatal("expected signal command after confirmation")
+	}
+	msg, ok := cmd().(signalResultMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("unexpected result %+v", msg)
+	}
+	if gotPID != 20 || !gotKill {
+		t.Errorf("signalProcess(%d, %v), want (20, true)", gotPID, gotKill)
+	}
+	if p.confirm != nil {
+		t.Error("confirmation should be cleared")
+	}
+}
+
+func TestProcessPanelSignalCancelled(t *testing.T) {
+	procs := sampleProcesses()
+	p := processPanel{open: true}
+	p, _ = p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}, procs, 10)
+	p, cmd := p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, procs, 10)
+	if cmd != nil || p.confirm != nil {
+		t.Errorf("expected cancellation, got cmd=%v confirm=%+v", cmd != nil, p.confirm)
+	}
+}
+
+func TestProcessPanelFilterTyping(t *testing.T) {
+	p := processPanel{open: true}
+	p, _ = p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")}, nil, 10)
+	for _, r := range "nod" {
+		p, _ = p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}, nil, 10)
+	}
+	p, _ = p.update(tea.KeyMsg{Type: tea.KeyBackspace}, nil, 10)
+	p, _ = p.update(tea.KeyMsg{Type: tea.KeyEnter}, nil, 10)
+	if p.filtering || p.filter != "no" {
+		t.Errorf("filter = %q filtering = %v, want \"no\" false", p.filter, p.filtering)
+	}
+}
+
+func TestCounterRate(t *testing.T) {
+	if got := counterRate(0, 2<<20, 2); got != 1 {
+		t.Errorf("counterRate = %v, want 1", got)
+	}
+	if got := counterRate(100, 50, 1); got != 0 {
+		t.Errorf("counterRate on reset = %v, want 0", got)
+	}
+}
+
+// When the selected process exits, the selection stays on its row.
+func TestProcessPanelSelectionSurvivesExit(t *testing.T) {
+	procs := sampleProcesses()
+	p := processPanel{open: true}
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
+	for range 2 {
+		p, _ = p.update(down, procs, 10)
+	}
+	if p.selectedPID != 21 {
+		t.Fatalf("selected %d, want 21", p.selectedPID)
+	}
+
+	var remaining []ProcessInfo
+	for _, proc := range procs {
+		if proc.PID != 21 {
+			remaining = append(remaining, proc)
+		}
+	}
+	p = p.follow(remaining)
+	if p.selectedPID != 1 || p.selectedRow != 2 {
+		t.Errorf("selection = pid %d row %d, want pid 1 row 2", p.selectedPID, p.selectedRow)
+	}
+	// The last row going away clamps to the new last row.
+	p = p.follow(remaining[1:])
+	if p.selectedPID != 20 || p.selectedRow != 1 {
+		t.Errorf("selection = pid %d row %d, want pid 20 row 1", p.selectedPID, p.selectedRow)
+	}
+}
@@ -3,6 +3,11 @@ package main
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tw93/mole/internal/theme"
+	"github.com/tw93/mole/internal/units"
 )
 
 func TestFormatRate(t *testing.T) {
@@ -12,24 +17,24 @@ func TestFormatRate(t *testing.T) {
 		want  string
 	}{
 		// Below threshold (< 0.01).
-		{"zero", 0, "0 MB/s"},
-		{"tiny", 0.001, "0 MB/s"},
-		{"just under threshold", 0.009, "0 MB/s"},
+		{"zero", 0, "0 MiB/s"},
+		{"tiny", 0.001, "0 MiB/s"},
+		{"just under threshold", 0.009, "0 MiB/s"},
 
 		// Small rates (0.01 to < 1) — 2 decimal places.
-		{"at threshold", 0.01, "0.01 MB/s"},
-		{"small rate", 0.5, "0.50 MB/s"},
-		{"just under 1", 0.99, "0.99 MB/s"},
+		{"at threshold", 0.01, "0.01 MiB/s"},
+		{"small rate", 0.5, "0.50 MiB/s"},
+		{"just under 1", 0.99, "0.99 MiB/s"},
 
 		// Medium rates (1 to < 10) — 1 decimal place.
-		{"exactly 1", 1.0, "1.0 MB/s"},
-		{"medium rate", 5.5, "5.5 MB/s"},
-		{"just under 10", 9.9, "9.9 MB/s"},
+		{"exactly 1", 1.0, "1.0 MiB/s"},
+		{"medium rate", 5.5, "5.5 MiB/s"},
+		{"just under 10", 9.9, "9.9 MiB/s"},
 
 		// Large rates (>= 10) — no decimal places.
-		{"exactly 10", 10.0, "10 MB/s"},
-		{"large rate", 100.5, "100 MB/s"},
-		{"very large", 1000.0, "1000 MB/s"},
+		{"exactly 10", 10.0, "10 MiB/s"},
+		{"large rate", 100.5, "100 MiB/s"},
+		{"very large", 1000.0, "1000 MiB/s"},
 	}
 
 	for _, tt := range tests {
@@ -86,6 +91,61 @@ func TestColorizePercent(t *testing.T) {
 	}
 }
 
+func TestMonoThemeMarksSeverity(t *testing.T) {
+	applyTheme(theme.Mono)
+	t.Cleanup(func() { applyTheme(theme.Dark) })
+
+	if got := stripANSI(colorizePercent(95, "▮▮▮▮▮")); got != "✖▮▮▮▮▮" {
+		t.Errorf("danger bar = %q", got)
+	}
+	if got := stripANSI(colorizeTemp(60)); got != "▲60.0°C" {
+		t.Errorf("warm temp = %q", got)
+	}
+	// Unmarked values keep the mark's cell so cards stay aligned.
+	if got := stripANSI(colorizeBattery(80, "80%")); got != " 80%" {
+		t.Errorf("healthy battery = %q", got)
+	}
+	if ok, warn := colorizeTemp(40), colorizeTemp(60); lipgloss.Width(ok) != lipgloss.Width(warn) {
+		t.Errorf("mark shifts the column: %q vs %q", stripANSI(ok), stripANSI(warn))
+	}
+	// Table rows keep their columns; the mark trails.
+	if got := stripANSI(paintLine(theme.LevelWarn, "/home  70.0")); got != "/home  70.0 ▲" {
+		t.Errorf("warn row = %q", got)
+	}
+	if got := paint(theme.LevelWarn, "x"); strings.Contains(got, "\x1b[38") {
+		t.Errorf("mono should not color: %q", got)
+	}
+}
+
+func TestUnitPreferences(t *testing.T) {
+	saved := unitFmt
+	t.Cleanup(func() { unitFmt = saved })
+	unitFmt = units.Formatter{SI: true, Bits: true, Fahrenheit: true, Group: ","}
+
+	if got := stripANSI(colorizeTemp(100)); got != "212.0°F" {
+		t.Errorf("temp = %q", got)
+	}
+	// Disk rates stay in bytes; network rates switch to bits.
+	if got := formatRate(1); got != "1.0 MB/s" {
+		t.Errorf("disk rate = %q", got)
+	}
+	if got := formatNetRate(1); got != "8.4 Mb/s" {
+		t.Errorf("network rate = %q", got)
+	}
+	if got := humanBytes(1_500_000_000); got != "1.5 GB" {
+		t.Errorf("size = %q", got)
+	}
+	card := renderConnectionsCard(ConnectionStatus{Established: 12345}, listenCardRows)
+	if !strings.Contains(strings.Join(card.lines, "\n"), "Estab  12,345") {
+		t.Errorf("counts should be grouped: %v", card.lines)
+	}
+
+	snap, _ := newTestCollector().Collect()
+	if snap.Units.Rates != "MiB/s" || snap.Units.Display.Network != "bits" {
+		t.Errorf("snapshot units = %+v", snap.Units)
+	}
+}
+
 func TestColorizeBattery(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -174,25 +234,25 @@ func TestHumanBytesShort(t *testing.T) {
 		{"999 bytes", 999, "999"},
 
 		// Kilobyte boundaries.
-		{"exactly 1KB", 1 << 10, "1K"},
+		{"exactly 1KB", 1 << 10, "1Ki"},
 		{"just under 1KB", (1 << 10) - 1, "1023"},
-		{"1.5KB rounds to 2K", 1536, "2K"},
-		{"999KB", 999 << 10, "999K"},
+		{"1.5KB rounds to 2K", 1536, "2Ki"},
+		{"999KB", 999 << 10, "999Ki"},
 
 		// Megabyte boundaries.
-		{"exactly 1MB", 1 << 20, "1M"},
-		{"just under 1MB", (1 << 20) - 1, "1024K"},
-		{"500MB", 500 << 20, "500M"},
+		{"exactly 1MB", 1 << 20, "1Mi"},
+		{"just under 1MB", (1 << 20) - 1, "1024Ki"},
+		{"500MB", 500 << 20, "500Mi"},
 
 		// Gigabyte boundaries.
-		{"exactly 1GB", 1 << 30, "1G"},
-		{"just under 1GB", (1 << 30) - 1, "1024M"},
-		{"100GB", 100 << 30, "100G"},
+		{"exactly 1GB", 1 << 30, "1Gi"},
+		{"just under 1GB", (1 << 30) - 1, "1024Mi"},
+		{"100GB", 100 << 30, "100Gi"},
 
 		// Terabyte boundaries.
-		{"exactly 1TB", 1 << 40, "1T"},
-		{"just under 1TB", (1 << 40) - 1, "1024G"},
-		{"2TB", 2 << 40, "2T"},
+		{"exactly 1TB", 1 << 40, "1Ti"},
+		{"just under 1TB", (1 << 40) - 1, "1024Gi"},
+		{"2TB", 2 << 40, "2Ti"},
 	}
 
 	for _, tt := range tests {
@@ -216,25 +276,25 @@ func TestHumanBytes(t *testing.T) {
 		{"one byte", 1, "1 B"},
 		{"1023 bytes", 1023, "1023 B"},
 
-		// Kilobyte boundaries (uses > not >=).
-		{"exactly 1KB", 1 << 10, "1024 B"},
-		{"just over 1KB", (1 << 10) + 1, "1.0 KB"},
-		{"1.5KB", 1536, "1.5 KB"},
+		// Kilobyte boundaries (same as mo analyze: >=).
+		{"exactly 1KB", 1 << 10, "1.0 KiB"},
+		{"just over 1KB", (1 << 10) + 1, "1.0 KiB"},
+		{"1.5KB", 1536, "1.5 KiB"},
 
-		// Megabyte boundaries (uses > not >=).
-		{"exactly 1MB", 1 << 20, "1024.0 KB"},
-		{"just over 1MB", (1 << 20) + 1, "1.0 MB"},
-		{"500MB", 500 << 20, "500.0 MB"},
+		// Megabyte boundaries.
+		{"exactly 1MB", 1 << 20, "1.0 MiB"},
+		{"just over 1MB", (1 << 20) + 1, "1.0 MiB"},
+		{"500MB", 500 << 20, "500.0 MiB"},
 
-		// Gigabyte boundaries (uses > not >=).
-		{"exactly 1GB", 1 << 30, "1024.0 MB"},
-		{"just over 1GB", (1 << 30) + 1, "1.0 GB"},
-		{"100GB", 100 << 30, "100.0 GB"},
+		// Gigabyte boundaries.
+		{"exactly 1GB", 1 << 30, "1.0 GiB"},
+		{"just over 1GB", (1 << 30) + 1, "1.0 GiB"},
+		{"100GB", 100 << 30, "100.0 GiB"},
 
-		// Terabyte boundaries (uses > not >=).
-		{"exactly 1TB", 1 << 40, "1024.0 GB"},
-		{"just over 1TB", (1 << 40) + 1, "1.0 TB"},
-		{"2TB", 2 << 40, "2.0 TB"},
+		// Terabyte boundaries.
+		{"exactly 1TB", 1 << 40, "1.0 TiB"},
+		{"just over 1TB", (1 << 40) + 1, "1.0 TiB"},
+		{"2TB", 2 << 40, "2.0 TiB"},
 	}
 
 	for _, tt := range tests {
@@ -259,20 +319,20 @@ func TestHumanBytesCompact(t *testing.T) {
 		{"1023 bytes", 1023, "1023"},
 
 		// Kilobyte boundaries (uses >= not >).
-		{"exactly 1KB", 1 << 10, "1.0K"},
-		{"1.5KB", 1536, "1.5K"},
+		{"exactly 1KB", 1 << 10, "1.0Ki"},
+		{"1.5KB", 1536, "1.5Ki"},
 
 		// Megabyte boundaries.
-		{"exactly 1MB", 1 << 20, "1.0M"},
-		{"500MB", 500 << 20, "500.0M"},
+		{"exactly 1MB", 1 << 20, "1.0Mi"},
+		{"500MB", 500 << 20, "500.0Mi"},
 
 		// Gigabyte boundaries.
-		{"exactly 1GB", 1 << 30, "1.0G"},
-		{"100GB", 100 << 30, "100.0G"},
+		{"exactly 1GB", 1 << 30, "1.0Gi"},
+		{"100GB", 100 << 30, "100.0Gi"},
 
 		// Terabyte boundaries.
-		{"exactly 1TB", 1 << 40, "1.0T"},
-		{"2TB", 2 << 40, "2.0T"},
+		{"exactly 1TB", 1 << 40, "1.0Ti"},
+		{"2TB", 2 << 40, "2.0Ti"},
 	}
 
 	for _, tt := range tests {
@@ -952,3 +1012,106 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRenderCPUZoom(t *testing.T) {
+	cpu := CPUStatus{
+		Usage: 40, LogicalCPU: 16, PCoreCount: 12, ECoreCount: 4, PCoreUsage: 50, ECoreUsage: 10,
+		FreqMHz: 2400, MaxFreqMHz: 4700,
+		Times: CPUTimes{User: 30, System: 10, Steal: 2, Idle: 58},
+	}
+	for i := range 16 {
+		cpu.PerCore = append(cpu.PerCore, float64(i*6))
+		cpu.PerCoreHistory = append(cpu.PerCoreHistory, []float64{0, 50, float64(i * 6)})
+	}
+
+	view := stripANSI(strings.Join(renderCPUZoom(cpu, ThermalStatus{}, CgroupStatus{}, 100, 30).lines, "\n"))
+	for _, want := range []string{
+		"P-core", "50.0% of 12", "E-core", "10.0% of 4",
+		"Clock  2.40 GHz of 4.70 GHz max",
+		"Time   user 30.0%  sys 10.0%  steal 2.0%  idle 58.0%",
+		"Core1 ", "Core16", "90.0%",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("zoom missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "iowait") {
+		t.Error("zero iowait should be left out")
+	}
+
+	// Too many cores for the space left: columns, then a count of the rest.
+	cpu.PerCore = make([]float64, 64)
+	lines := renderCPUZoom(cpu, ThermalStatus{}, CgroupStatus{}, 60, 14).lines
+	last := stripANSI(lines[len(lines)-1])
+	if !strings.HasSuffix(last, "more cores") {
+		t.Errorf("last line = %q, want a count of hidden cores", last)
+	}
+	if !strings.Contains(stripANSI(strings.Join(lines, "\n")), "Core1 ") || len(lines) > 14 {
+		t.Errorf("grid overflowed %d lines:\n%s", len(lines), stripANSI(strings.Join(lines, "\n")))
+	}
+}
+
+func TestRenderMemoryBreakdown(t *testing.T) {
+	mem := MemoryStatus{
+		Total: 16 << 30, Used: 12 << 30, UsedPercent: 75,
+		Breakdown: []MemorySegment{
+			{"wired", 2 << 30}, {"active", 4 << 30}, {"inactive", 4 << 30}, {"compressed", 2 << 30},
+		},
+		SwapInRate: 1.5, SwapOutRate: 0.5,
+		PageFaultRate: 1200, MajorFaultRate: 3,
+		Top: []MemoryConsumer{{PID: 10, Name: "Safari", RSS: 3 << 30}, {PID: 11, Name: "Xcode", RSS: 2 << 30}},
+	}
+	out := stripANSI(strings.Join(renderMemoryCard(mem, CgroupStatus{}).lines, "\n"))
+	for _, want := range []string{"Kinds  ████████████░░░░", "wired", "compressed", "Paging in", "Faults", "Top    Safari", "Xcode"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("memory card missing %q:\n%s", want, out)
+		}
+	}
+
+	// Mono themes tell the segments apart by glyph.
+	applyTheme(theme.Mono)
+	t.Cleanup(func() { applyTheme(theme.Dark) })
+	if got := stripANSI(stackedBar(mem.Breakdown, mem.Total)); got != "██▓▓▓▓▒▒▒▒▚▚░░░░" {
+		t.Errorf("mono bar = %q", got)
+	}
+
+	// An idle machine shows neither paging nor a top list.
+	quiet := stripANSI(strings.Join(renderMemoryCard(MemoryStatus{Total: 16 << 30}, CgroupStatus{}).lines, "\n"))
+	if strings.Contains(quiet, "Paging") || strings.Contains(quiet, "Top") || strings.Contains(quiet, "Kinds") {
+		t.Errorf("quiet memory card:\n%s", quiet)
+	}
+}
+
+func TestRenderLeakCard(t *testing.T) {
+	now := time.Now()
+	var leaks []LeakSuspect
+	for i := range 6 {
+		leaks = append(leaks, LeakSuspect{
+			PID: int32(i), Name: "Electron Helper", RSS: 2 << 30, Rate: 1, Since: now.Add(-3 * time.Minute),
+		})
+	}
+	card := renderLeakCard(leaks, now)
+	if card.title != "Suspected Leaks" || len(card.lines) != leakCardRows+1 {
+		t.Fatalf("card = %+v", card)
+	}
+	if got := stripANSI(card.lines[0]); !strings.Contains(got, "+60") || !strings.HasSuffix(got, "for 3m") {
+		t.Errorf("leak line = %q", got)
+	}
+	if got := stripANSI(card.lines[leakCardRows]); got != "+2 more growing" {
+		t.Errorf("overflow line = %q", got)
+	}
+}
+
+// Without a theme in status.json or MO_THEME, the dashboard keeps its
+// original dark palette rather than probing the terminal.
+func TestDefaultThemeIsDark(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("MO_THEME", "")
+	if got := resolveTheme(defaultPrefs().Theme); got.Name != "dark" {
+		t.Errorf("default theme = %s", got.Name)
+	}
+	t.Setenv("MO_THEME", "colorblind")
+	if got := resolveTheme(defaultPrefs().Theme); got.Name != "colorblind" {
+		t.Errorf("MO_THEME theme = %s", got.Name)
+	}
+}
@@ -0,0 +1,191 @@
+// Package theme holds the color palettes shared by mo status and mo analyze.
+package theme
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Role names what a piece of text means; each theme picks its color.
+type Role int
+
+const (
+	Title     Role = iota // Headings, bold
+	Primary               // Accents such as the header cat
+	Subtle                // Labels and secondary text
+	Line                  // Separators
+	Info                  // Neutral highlights
+	Highlight             // Cursor and selected rows in lists
+	Good                  // Best of several healthy levels
+	Fair                  // Between Good and Warn on a graded score
+	OK
+	Warn
+	Caution // Between Warn and Danger
+	Danger
+	Critical // Worst of a graded score
+	Selected // Focused item, drawn on a background
+	roleCount
+)
+
+// Level is a severity that monochrome themes spell out with a symbol.
+type Level int
+
+const (
+	LevelOK Level = iota
+	LevelWarn
+	LevelDanger
+)
+
+// Theme is a named palette. Colors are lipgloss color strings ("#RRGGBB"
+// or an ANSI index); a theme without colors is monochrome.
+type Theme struct {
+	Name       string
+	fg         [roleCount]string
+	selectedBg string
+	sgr        [roleCount]string // SGR parameters ANSI uses instead of fg, if set
+}
+
+// Names lists the accepted theme names. "auto" is the default, dark;
+// "detect" opts in to picking dark or light from the terminal background.
+var Names = []string{"auto", "dark", "light", "high-contrast", "colorblind", "mono", "detect"}
+
+var (
+	// Dark is the default and keeps mole's original colors: these for mo
+	// status, and for mo analyze the terminal's own 16-color palette.
+	Dark = Theme{Name: "dark", selectedBg: "#5F5F87", fg: [roleCount]string{
+		Title: "#C79FD7", Primary: "#BD93F9", Subtle: "#737373", Line: "#404040",
+		Info: "#5F87D7", Highlight: "#5FD7D7",
+		Good: "#87FF87", Fair: "#87D787", OK: "#A5D6A7", Warn: "#FFD75F", Caution: "#FFAF5F",
+		Danger: "#FF5F5F", Critical: "#FF6B6B",
+		Selected: "#FFFFFF",
+	}, sgr: [roleCount]string{
+		Title: "1;35", Primary: "0;35", Subtle: "0;90", Info: "0;34", Highlight: "0;36",
+		OK: "0;32", Warn: "0;33", Danger: "0;31",
+	}}
+	Light = Theme{Name: "light", selectedBg: "#5F5F87", fg: [roleCount]string{
+		Title: "#8839A8", Primary: "#6A3FB5", Subtle: "#6C6C6C", Line: "#BCBCBC",
+		Info: "#1565C0", Highlight: "#00838F",
+		Good: "#1B5E20", Fair: "#33691E", OK: "#2E7D32", Warn: "#9A6700", Caution: "#C45500",
+		Danger: "#C62828", Critical: "#B71C1C",
+		Selected: "#FFFFFF",
+	}}
+	HighContrast = Theme{Name: "high-contrast", selectedBg: "#FFFF00", fg: [roleCount]string{
+		Title: "#FF87FF", Primary: "#D7AFFF", Subtle: "#D0D0D0", Line: "#808080",
+		Info: "#00AFFF", Highlight: "#00FFFF",
+		Good: "#00FF00", Fair: "#AFFF00", OK: "#5FFF5F", Warn: "#FFFF00", Caution: "#FFAF00",
+		Danger: "#FF0000", Critical: "#FF005F",
+		Selected: "#000000",
+	}}
+	// Colorblind uses the Okabe-Ito palette: severity runs blue, yellow,
+	// orange, vermillion, which stays distinct without red-green vision.
+	Colorblind = Theme{Name: "colorblind", selectedBg: "#0072B2", fg: [roleCount]string{
+		Title: "#CC79A7", Primary: "#CC79A7", Subtle: "#999999", Line: "#4E4E4E",
+		Info: "#56B4E9", Highlight: "#009E73",
+		Good: "#0072B2", Fair: "#56B4E9", OK: "#56B4E9", Warn: "#F0E442", Caution: "#E69F00",
+		Danger: "#D55E00", Critical: "#D55E00",
+		Selected: "#FFFFFF",
+	}}
+	Mono = Theme{Name: "mono"}
+)
+
+// Lookup returns the theme called name; "auto", "detect" and unknown names
+// report false.
+func Lookup(name string) (Theme, bool) {
+	for _, t := range []Theme{Dark, Light, HighContrast, Colorblind, Mono} {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// Resolve picks the theme to draw with. NO_COLOR always wins; "auto" or an
+// unknown name is Dark. Only "detect" queries the terminal background,
+// which can stall or guess wrong over ssh and tmux.
+func Resolve(name string) Theme {
+	if os.Getenv("NO_COLOR") != "" {
+		return Mono
+	}
+	if t, ok := Lookup(name); ok {
+		return t
+	}
+	if name == "detect" && !lipgloss.HasDarkBackground() {
+		return Light
+	}
+	return Dark
+}
+
+// IsMono reports whether the theme draws without color.
+func (t Theme) IsMono() bool {
+	return t.fg[OK] == ""
+}
+
+// Style returns the lipgloss style for r.
+func (t Theme) Style(r Role) lipgloss.Style {
+	s := lipgloss.NewStyle()
+	if t.IsMono() {
+		switch r {
+		case Title, Danger, Critical:
+			return s.Bold(true)
+		case Subtle, Line:
+			return s.Faint(true)
+		case Selected:
+			return s.Reverse(true)
+		}
+		return s
+	}
+	s = s.Foreground(lipgloss.Color(t.fg[r]))
+	switch r {
+	case Title:
+		s = s.Bold(true)
+	case Selected:
+		s = s.Background(lipgloss.Color(t.selectedBg))
+	}
+	return s
+}
+
+// ANSI returns the escape sequence that starts r, for views that build
+// strings by hand. Colors are reduced to what the terminal supports.
+func (t Theme) ANSI(r Role) string {
+	if t.IsMono() {
+		switch r {
+		case Title, Danger, Critical:
+			return "\033[1m"
+		case Subtle, Line:
+			return "\033[2m"
+		case Selected:
+			return "\033[7m"
+		}
+		return ""
+	}
+	if t.sgr[r] != "" {
+		return "\033[" + t.sgr[r] + "m"
+	}
+	seq := lipgloss.ColorProfile().Color(t.fg[r]).Sequence(false)
+	if seq == "" {
+		return ""
+	}
+	switch r {
+	case Title:
+		seq = "1;" + seq
+	case Selected:
+		seq += ";" + lipgloss.ColorProfile().Color(t.selectedBg).Sequence(true)
+	}
+	return "\033[" + seq + "m"
+}
+
+// Mark returns the symbol a monochrome theme shows for l, or "" when color
+// already carries it.
+func (t Theme) Mark(l Level) string {
+	if !t.IsMono() {
+		return ""
+	}
+	switch l {
+	case LevelWarn:
+		return "▲"
+	case LevelDanger:
+		return "✖"
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupCoversNames(t *testing.T) {
+	for _, name := range Names {
+		th, ok := Lookup(name)
+		if name == "auto" || name == "detect" {
+			if ok {
+				t.Errorf("%s is resolved, not looked up", name)
+			}
+			continue
+		}
+		if !ok || th.Name != name {
+			t.Errorf("Lookup(%q) = %q, %v", name, th.Name, ok)
+		}
+		if th.IsMono() != (name == "mono") {
+			t.Errorf("%s: IsMono = %v", name, th.IsMono())
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if got := Resolve("colorblind"); got.Name != "colorblind" {
+		t.Errorf("Resolve(colorblind) = %s", got.Name)
+	}
+	// Without an explicit choice the palette stays the original dark one
+	// whatever the terminal background.
+	for _, name := range []string{"", "auto", "no-such-theme"} {
+		if got := Resolve(name); got.Name != "dark" {
+			t.Errorf("Resolve(%q) = %s, want dark", name, got.Name)
+		}
+	}
+	t.Setenv("NO_COLOR", "1")
+	if got := Resolve("dark"); got.Name != "mono" {
+		t.Errorf("NO_COLOR should force mono, got %s", got.Name)
+	}
+}
+
+func TestMonoUsesSymbolsNotColor(t *testing.T) {
+	for _, r := range []Role{Title, Subtle, Info, Highlight, OK, Warn, Danger, Selected} {
+		if seq := Mono.ANSI(r); strings.Contains(seq, "38;") || strings.Contains(seq, "[3") {
+			t.Errorf("role %d: mono sequence %q sets a color", r, seq)
+		}
+	}
+	if Mono.ANSI(Danger) != "\033[1m" || Mono.ANSI(OK) != "" {
+		t.Error("mono should set danger apart by weight only")
+	}
+	if Mono.Mark(LevelWarn) == "" || Mono.Mark(LevelDanger) == "" || Mono.Mark(LevelOK) != "" {
+		t.Error("mono marks warn and danger only")
+	}
+	if Mono.Mark(LevelWarn) == Mono.Mark(LevelDanger) {
+		t.Error("warn and danger need different marks")
+	}
+	if Dark.Mark(LevelDanger) != "" {
+		t.Error("colored themes carry severity in color")
+	}
+}
+
+func TestSeverityColorsDistinct(t *testing.T) {
+	for _, th := range []Theme{Dark, Light, HighContrast, Colorblind} {
+		seen := map[string]Role{}
+		for _, r := range []Role{Good, OK, Warn, Caution, Danger} {
+			if prev, dup := seen[th.fg[r]]; dup {
+				t.Errorf("%s: roles %d and %d share %s", th.Name, prev, r, th.fg[r])
+			}
+			seen[th.fg[r]] = r
+		}
+	}
+}
+
+// The default theme keeps mole's colors from before themes existed.
+func TestDarkKeepsOriginalColors(t *testing.T) {
+	for r, want := range map[Role]string{
+		Title: "\033[1;35m", Subtle: "\033[0;90m", Danger: "\033[0;31m", Warn: "\033[0;33m",
+		OK: "\033[0;32m", Info: "\033[0;34m", Highlight: "\033[0;36m",
+	} {
+		if got := Dark.ANSI(r); got != want {
+			t.Errorf("role %d: ANSI = %q, want %q", r, got, want)
+		}
+	}
+	for r, want := range map[Role]string{
+		Title: "#C79FD7", Subtle: "#737373", Warn: "#FFD75F", Danger: "#FF5F5F", OK: "#A5D6A7",
+		Good: "#87FF87", Fair: "#87D787", Caution: "#FFAF5F", Critical: "#FF6B6B",
+	} {
+		if got := Dark.fg[r]; got != want {
+			t.Errorf("role %d: color = %s, want %s", r, got, want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+// Package units formats sizes, rates, temperatures and counts the same way
+// for mo status and mo analyze.
+package units
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Accepted preference values, first is the default.
+var (
+	ByteNames = []string{"iec", "si"}
+	RateNames = []string{"bytes", "bits"}
+	TempNames = []string{"celsius", "fahrenheit"}
+)
+
+// Formatter renders values in the user's chosen units.
+type Formatter struct {
+	SI         bool   // Sizes step by 1000 instead of 1024
+	Bits       bool   // Network rates in bits per second
+	Fahrenheit bool   // Temperatures in °F
+	Group      string // Thousands separator for counts
+}
+
+// Meta describes a Formatter for JSON output.
+type Meta struct {
+	Bytes       string // iec or si
+	Network     string // bytes or bits
+	Temperature string // celsius or fahrenheit
+	Grouping    string // Thousands separator
+}
+
+// Default returns IEC sizes, byte rates and Celsius, grouping counts for
+// the current locale.
+func Default() Formatter {
+	return Formatter{Group: LocaleGroup()}
+}
+
+// Set applies one preference value such as "si", "bits" or "fahrenheit".
+func (f *Formatter) Set(value string) error {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "iec":
+		f.SI = false
+	case "si":
+		f.SI = true
+	case "bytes":
+		f.Bits = false
+	case "bits":
+		f.Bits = true
+	case "celsius", "c":
+		f.Fahrenheit = false
+	case "fahrenheit", "f":
+		f.Fahrenheit = true
+	default:
+		return fmt.Errorf("unknown unit %q", value)
+	}
+	return nil
+}
+
+// FromEnv applies the comma-separated values in MO_UNITS, e.g.
+// "si,bits,fahrenheit". Unknown values are reported and skipped.
+func (f Formatter) FromEnv() (Formatter, error) {
+	var firstErr error
+	for value := range strings.SplitSeq(os.Getenv("MO_UNITS"), ",") {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if err := f.Set(value); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("MO_UNITS: %w", err)
+		}
+	}
+	return f, firstErr
+}
+
+// Meta reports the settings by their preference names.
+func (f Formatter) Meta() Meta {
+	m := Meta{Bytes: ByteNames[0], Network: RateNames[0], Temperature: TempNames[0], Grouping: f.Group}
+	if f.SI {
+		m.Bytes = ByteNames[1]
+	}
+	if f.Bits {
+		m.Network = RateNames[1]
+	}
+	if f.Fahrenheit {
+		m.Temperature = TempNames[1]
+	}
+	return m
+}
+
+func (f Formatter) base() float64 {
+	if f.SI {
+		return 1000
+	}
+	return 1024
+}
+
+// scale returns v in the largest unit it reaches and that unit's index
+// into "KMGTPE", or -1 below one kilobyte.
+func (f Formatter) scale(v float64) (float64, int) {
+	exp := -1
+	for v >= f.base() && exp < 5 {
+		v /= f.base()
+		exp++
+	}
+	return v, exp
+}
+
+// prefix names a unit: "Ki", "Mi", "Gi" for IEC sizes and "k", "M", "G"
+// for SI ones.
+func (f Formatter) prefix(exp int) string {
+	p := string("KMGTPE"[exp])
+	if !f.SI {
+		return p + "i"
+	}
+	if p == "K" {
+		return "k"
+	}
+	return p
+}
+
+// Bytes formats a size as "1.5 GiB", or "1.5 GB" in SI.
+func (f Formatter) Bytes(n uint64) string {
+	v, exp := f.scale(float64(n))
+	if exp < 0 {
+		return strconv.FormatUint(n, 10) + " B"
+	}
+	return fmt.Sprintf("%.1f %sB", v, f.prefix(exp))
+}
+
+// BytesShort formats a size with no decimals and a short unit, "2Gi" or
+// "2G" in SI.
+func (f Formatter) BytesShort(n uint64) string {
+	v, exp := f.scale(float64(n))
+	if exp < 0 {
+		return strconv.FormatUint(n, 10)
+	}
+	return fmt.Sprintf("%.0f%s", v, f.prefix(exp))
+}
+
+// BytesCompact formats a size with one decimal and a short unit, "1.5Gi"
+// or "1.5G" in SI.
+func (f Formatter) BytesCompact(n uint64) string {
+	v, exp := f.scale(float64(n))
+	if exp < 0 {
+		return strconv.FormatUint(n, 10)
+	}
+	return fmt.Sprintf("%.1f%s", v, f.prefix(exp))
+}
+
+// megabytes converts bytes per second to the figure shown for rates, in
+// RateUnit.
+func (f Formatter) megabytes(bytesPerSec float64) float64 {
+	return bytesPerSec / (f.base() * f.base())
+}
+
+// RateUnit is the unit Rate and RateCompact use, "MiB/s" or "MB/s" in SI.
+func (f Formatter) RateUnit() string {
+	return f.prefix(1) + "B/s"
+}
+
+// Rate formats a storage rate as "1.5 MiB/s", or "1.5 MB/s" in SI.
+func (f Formatter) Rate(bytesPerSec float64) string {
+	return rate(f.megabytes(bytesPerSec), f.RateUnit())
+}
+
+// RateCompact is Rate without the unit, for dense rows that label it once.
+func (f Formatter) RateCompact(bytesPerSec float64) string {
+	mb := f.megabytes(bytesPerSec)
+	switch {
+	case mb < 0.01:
+		return "0"
+	case mb < 10:
+		return fmt.Sprintf("%.1f", mb)
+	default:
+		return fmt.Sprintf("%.0f", mb)
+	}
+}
+
+// NetRate formats a network rate, in megabits when Bits is set. Bit rates
+// always step by 1000, as link speeds do.
+func (f Formatter) NetRate(bytesPerSec float64) string {
+	if f.Bits {
+		return rate(bytesPerSec*8/1e6, "Mb/s")
+	}
+	return f.Rate(bytesPerSec)
+}
+
+func rate(v float64, unit string) string {
+	switch {
+	case v < 0.01:
+		return "0 " + unit
+	case v < 1:
+		return fmt.Sprintf("%.2f %s", v, unit)
+	case v < 10:
+		return fmt.Sprintf("%.1f %s", v, unit)
+	default:
+		return fmt.Sprintf("%.0f %s", v, unit)
+	}
+}
+
+// Temp formats a Celsius reading in the chosen scale, "45.0°C".
+func (f Formatter) Temp(celsius float64) string {
+	if f.Fahrenheit {
+		return fmt.Sprintf("%.1f°F", celsius*9/5+32)
+	}
+	return fmt.Sprintf("%.1f°C", celsius)
+}
+
+// Count formats n with thousands grouping, "12,345".
+func (f Formatter) Count(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if f.Group == "" || len(digits) <= 3 {
+		return sign + digits
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	lead := len(digits) % 3
+	if lead == 0 {
+		lead = 3
+	}
+	b.WriteString(digits[:lead])
+	for i := lead; i < len(digits); i += 3 {
+		b.WriteString(f.Group)
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// CountCompact formats n in thousands or millions for progress lines that
+// update too quickly to read, "1.5k" and "2.3M".
+func (f Formatter) CountCompact(n int64) string {
+	switch {
+	case n < 1000:
+		return f.Count(n)
+	case n < 1000000:
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	default:
+		return fmt.Sprintf("%.1fM", float64(n)/1000000)
+	}
+}
+
+// LocaleGroup returns the thousands separator for LC_ALL, LC_NUMERIC or
+// LANG, in that order; "," when none is set.
+func LocaleGroup() string {
+	for _, key := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			return groupFor(v)
+		}
+	}
+	return ","
+}
+
+// groupFor maps a locale such as "de_DE.UTF-8" to its separator.
+func groupFor(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	lang, region, _ := strings.Cut(locale, "_")
+	switch {
+	case region == "CH" || region == "LI":
+		return "'"
+	case lang == "es" && (region == "MX" || region == "US"):
+		return ","
+	case lang == "pt" && region == "PT":
+		return "\u00a0"
+	}
+	switch lang {
+	case "de", "da", "es", "id", "it", "nl", "pt", "tr", "el", "ro", "sl", "hr", "sr", "vi":
+		return "."
+	case "fr", "ru", "pl", "cs", "sk", "fi", "sv", "nb", "nn", "no", "uk", "hu", "bg", "et", "lv", "lt":
+		// A no-break space, so the number never wraps.
+		return "\u00a0"
+	}
+	return ","
+}
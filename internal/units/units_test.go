@@ -0,0 +1,160 @@
+package units
+
+import "testing"
+
+func TestBytes(t *testing.T) {
+	iec := Formatter{}
+	si := Formatter{SI: true}
+	tests := []struct {
+		n                 uint64
+		iec, si           string
+		iecShort, siShort string
+	}{
+		{0, "0 B", "0 B", "0", "0"},
+		{999, "999 B", "999 B", "999", "999"},
+		{1000, "1000 B", "1.0 kB", "1000", "1k"},
+		{1024, "1.0 KiB", "1.0 kB", "1Ki", "1k"},
+		{1536, "1.5 KiB", "1.5 kB", "2Ki", "2k"},
+		{500 << 20, "500.0 MiB", "524.3 MB", "500Mi", "524M"},
+		{1 << 40, "1.0 TiB", "1.1 TB", "1Ti", "1T"},
+		{1 << 50, "1.0 PiB", "1.1 PB", "1Pi", "1P"},
+	}
+	for _, tt := range tests {
+		if got := iec.Bytes(tt.n); got != tt.iec {
+			t.Errorf("iec Bytes(%d) = %q, want %q", tt.n, got, tt.iec)
+		}
+		if got := si.Bytes(tt.n); got != tt.si {
+			t.Errorf("si Bytes(%d) = %q, want %q", tt.n, got, tt.si)
+		}
+		if got := iec.BytesShort(tt.n); got != tt.iecShort {
+			t.Errorf("iec BytesShort(%d) = %q, want %q", tt.n, got, tt.iecShort)
+		}
+		if got := si.BytesShort(tt.n); got != tt.siShort {
+			t.Errorf("si BytesShort(%d) = %q, want %q", tt.n, got, tt.siShort)
+		}
+	}
+	if got := iec.BytesCompact(3 << 29); got != "1.5Gi" {
+		t.Errorf("iec BytesCompact = %q", got)
+	}
+	if got := si.BytesCompact(2_500_000_000); got != "2.5G" {
+		t.Errorf("si BytesCompact = %q", got)
+	}
+}
+
+func TestRates(t *testing.T) {
+	const mib = 1 << 20
+	tests := []struct {
+		f           Formatter
+		bytesPerSec float64
+		rate, net   string
+	}{
+		{Formatter{}, 0.005 * mib, "0 MiB/s", "0 MiB/s"},
+		{Formatter{}, 0.5 * mib, "0.50 MiB/s", "0.50 MiB/s"},
+		{Formatter{}, 12.4 * mib, "12 MiB/s", "12 MiB/s"},
+		{Formatter{SI: true}, 5e6, "5.0 MB/s", "5.0 MB/s"},
+		// Bits apply to the network only, and always in steps of 1000.
+		{Formatter{Bits: true}, 125_000, "0.12 MiB/s", "1.0 Mb/s"},
+		{Formatter{Bits: true, SI: true}, 12.5e6, "12 MB/s", "100 Mb/s"},
+	}
+	if iec, si := (Formatter{}).RateUnit(), (Formatter{SI: true}).RateUnit(); iec != "MiB/s" || si != "MB/s" {
+		t.Errorf("RateUnit = %q and %q in SI", iec, si)
+	}
+	for _, tt := range tests {
+		if got := tt.f.Rate(tt.bytesPerSec); got != tt.rate {
+			t.Errorf("%+v Rate(%v) = %q, want %q", tt.f, tt.bytesPerSec, got, tt.rate)
+		}
+		if got := tt.f.NetRate(tt.bytesPerSec); got != tt.net {
+			t.Errorf("%+v NetRate(%v) = %q, want %q", tt.f, tt.bytesPerSec, got, tt.net)
+		}
+	}
+}
+
+func TestTemp(t *testing.T) {
+	if got := (Formatter{}).Temp(45); got != "45.0°C" {
+		t.Errorf("celsius = %q", got)
+	}
+	if got := (Formatter{Fahrenheit: true}).Temp(100); got != "212.0°F" {
+		t.Errorf("fahrenheit = %q", got)
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		group string
+		n     int64
+		want  string
+	}{
+		{",", 0, "0"},
+		{",", 999, "999"},
+		{",", 1000, "1,000"},
+		{",", 1234567, "1,234,567"},
+		{",", -45678, "-45,678"},
+		{".", 100000, "100.000"},
+		{"", 1234567, "1234567"},
+	}
+	for _, tt := range tests {
+		if got := (Formatter{Group: tt.group}).Count(tt.n); got != tt.want {
+			t.Errorf("Count(%d) with %q = %q, want %q", tt.n, tt.group, got, tt.want)
+		}
+	}
+}
+
+func TestCountCompact(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0"},
+		{500, "500"},
+		{999, "999"},
+		{1000, "1.0k"},
+		{1500, "1.5k"},
+		{999999, "1000.0k"},
+		{1000000, "1.0M"},
+		{1500000, "1.5M"},
+	}
+	for _, tt := range tests {
+		if got := Default().CountCompact(tt.n); got != tt.want {
+			t.Errorf("CountCompact(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestLocaleGroup(t *testing.T) {
+	tests := []struct {
+		lcAll, lang string
+		want        string
+	}{
+		{"", "", ","},
+		{"", "en_US.UTF-8", ","},
+		{"", "de_DE.UTF-8", "."},
+		{"", "de_CH.UTF-8", "'"},
+		{"", "fr_FR.UTF-8", "\u00a0"},
+		{"", "es_MX", ","},
+		{"C", "de_DE.UTF-8", ","}, // LC_ALL wins
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_NUMERIC", "")
+		t.Setenv("LANG", tt.lang)
+		if got := LocaleGroup(); got != tt.want {
+			t.Errorf("LC_ALL=%q LANG=%q: %q, want %q", tt.lcAll, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("MO_UNITS", "si, bits,fahrenheit")
+	f, err := Formatter{}.FromEnv()
+	if err != nil || !f.SI || !f.Bits || !f.Fahrenheit {
+		t.Fatalf("FromEnv = %+v, %v", f, err)
+	}
+	if m := f.Meta(); m.Bytes != "si" || m.Network != "bits" || m.Temperature != "fahrenheit" {
+		t.Errorf("Meta = %+v", m)
+	}
+
+	t.Setenv("MO_UNITS", "furlongs,si")
+	if f, err = (Formatter{}).FromEnv(); err == nil || !f.SI {
+		t.Errorf("unknown values should be reported and skipped: %+v, %v", f, err)
+	}
+}